@@ -0,0 +1,12 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+// the settings of the built-in logs index template
+data "elasticstack_elasticsearch_component_template" "logs_settings" {
+  name = "logs@settings"
+}
+
+output "logs_settings" {
+  value = jsondecode(data.elasticstack_elasticsearch_component_template.logs_settings.template[0].settings)
+}
@@ -0,0 +1,11 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_index" "my_index" {
+  name = "my-index"
+}
+
+output "my_index_docs_count" {
+  value = data.elasticstack_elasticsearch_index.my_index.docs_count
+}
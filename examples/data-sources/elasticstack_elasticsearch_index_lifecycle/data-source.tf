@@ -0,0 +1,25 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_index_lifecycle" "logs" {
+  name = "logs"
+}
+
+// keep the rollover of the built-in policy, deleting the indices after a week
+resource "elasticstack_elasticsearch_index_lifecycle" "logs_short" {
+  name = "logs-short"
+
+  hot {
+    min_age = data.elasticstack_elasticsearch_index_lifecycle.logs.hot[0].min_age
+    rollover {
+      max_age                = data.elasticstack_elasticsearch_index_lifecycle.logs.hot[0].rollover[0].max_age
+      max_primary_shard_size = data.elasticstack_elasticsearch_index_lifecycle.logs.hot[0].rollover[0].max_primary_shard_size
+    }
+  }
+
+  delete {
+    min_age = "7d"
+    delete {}
+  }
+}
@@ -0,0 +1,30 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_index_template" "logs" {
+  name = "logs"
+}
+
+resource "elasticstack_elasticsearch_component_template" "logs_custom" {
+  name = "logs-custom-mappings"
+
+  template {
+    mappings = jsonencode({
+      properties = {
+        "service.name" = { type = "keyword" }
+      }
+    })
+  }
+}
+
+// extend the built-in template without taking ownership of it
+resource "elasticstack_elasticsearch_index_template" "logs_custom" {
+  name = "logs-custom"
+
+  priority       = data.elasticstack_elasticsearch_index_template.logs.priority + 1
+  index_patterns = ["logs-custom-*"]
+  composed_of    = concat(data.elasticstack_elasticsearch_index_template.logs.composed_of, [elasticstack_elasticsearch_component_template.logs_custom.name])
+
+  data_stream {}
+}
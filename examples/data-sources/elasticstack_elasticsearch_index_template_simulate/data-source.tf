@@ -0,0 +1,25 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+// the settings, mappings and aliases a new logs index would get
+data "elasticstack_elasticsearch_index_template_simulate" "logs" {
+  index_name = "logs-000001"
+}
+
+// an index template definition, simulated before being created
+data "elasticstack_elasticsearch_index_template_simulate" "metrics" {
+  template = jsonencode({
+    index_patterns = ["metrics-*"]
+    composed_of    = ["metrics-mappings"]
+    priority       = 200
+  })
+}
+
+output "logs_mappings" {
+  value = jsondecode(data.elasticstack_elasticsearch_index_template_simulate.logs.mappings)
+}
+
+output "metrics_overlapping_templates" {
+  value = [for template in data.elasticstack_elasticsearch_index_template_simulate.metrics.overlapping : template.name]
+}
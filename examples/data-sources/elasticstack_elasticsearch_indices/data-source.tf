@@ -0,0 +1,13 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+// the indices rolled over by ILM, including the closed ones
+data "elasticstack_elasticsearch_indices" "logs" {
+  pattern          = "logs-*"
+  expand_wildcards = "open,closed"
+}
+
+output "logs_indices" {
+  value = [for index in data.elasticstack_elasticsearch_indices.logs.indices : index.name]
+}
@@ -0,0 +1,11 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_security_service_accounts" "elastic" {
+  namespace = "elastic"
+}
+
+output "fleet_server_tokens" {
+  value = [for account in data.elasticstack_elasticsearch_security_service_accounts.elastic.service_accounts : account.tokens if account.name == "elastic/fleet-server"][0]
+}
@@ -0,0 +1,7 @@
+provider "elasticstack" {
+  elasticsearch {
+    cloud_id = "my-deployment:dXMtY2VudHJhbDEuZ2NwLmNsb3VkLmVzLmlvJGVzLXV1aWQka2liYW5hLXV1aWQ="
+    api_key  = "base64encodedapikeyhere=="
+  }
+  kibana {}
+}
@@ -0,0 +1,14 @@
+provider "elasticstack" {
+  elasticsearch {
+    username  = "elastic"
+    password  = "changeme"
+    endpoints = ["http://localhost:9200"]
+
+    retry {
+      max_attempts    = 5
+      retry_on_status = [429, 502, 503, 504]
+      initial_backoff = "1s"
+      max_wait        = "30s"
+    }
+  }
+}
@@ -1 +1,3 @@
+# The cluster UUID can be omitted, the UUID of the connected cluster is used then
 terraform import elasticstack_elasticsearch_component_template.my_template <cluster_uuid>/<component_name>
+terraform import elasticstack_elasticsearch_component_template.my_template <component_name>
@@ -1,2 +1,3 @@
+# The cluster UUID can be omitted, the UUID of the connected cluster is used then
 terraform import elasticstack_elasticsearch_data_stream.my_data_stream <cluster_uuid>/<data_stream_name>
-
+terraform import elasticstack_elasticsearch_data_stream.my_data_stream <data_stream_name>
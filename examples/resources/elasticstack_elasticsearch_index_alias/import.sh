@@ -0,0 +1,3 @@
+terraform import elasticstack_elasticsearch_index_alias.my_alias <cluster_uuid>/<alias name>
+# The cluster UUID can be omitted, the UUID of the connected cluster is used then
+terraform import elasticstack_elasticsearch_index_alias.my_alias <alias name>
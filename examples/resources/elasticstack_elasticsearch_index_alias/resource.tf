@@ -0,0 +1,35 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "v1" {
+  name = "my-index-v1"
+
+  lifecycle {
+    ignore_changes = [alias]
+  }
+}
+
+resource "elasticstack_elasticsearch_index" "v2" {
+  name = "my-index-v2"
+
+  lifecycle {
+    ignore_changes = [alias]
+  }
+}
+
+resource "elasticstack_elasticsearch_index_alias" "my_alias" {
+  name = "my-alias"
+
+  index {
+    name = elasticstack_elasticsearch_index.v1.name
+    filter = jsonencode({
+      term = { "user.id" = "kimchy" }
+    })
+  }
+
+  index {
+    name           = elasticstack_elasticsearch_index.v2.name
+    is_write_index = true
+  }
+}
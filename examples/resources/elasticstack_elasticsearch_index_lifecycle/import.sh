@@ -1 +1,3 @@
+# The cluster UUID can be omitted, the UUID of the connected cluster is used then
 terraform import elasticstack_elasticsearch_index_lifecycle.my_ilm <cluster_uuid>/<ilm_name>
+terraform import elasticstack_elasticsearch_index_lifecycle.my_ilm <ilm_name>
@@ -0,0 +1,30 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "logs" {
+  name             = "logs-2023.03"
+  number_of_shards = 4
+
+  alias {
+    name = "logs-current"
+  }
+
+  # the alias is moved to the shrunk index
+  lifecycle {
+    ignore_changes = [alias]
+  }
+}
+
+resource "elasticstack_elasticsearch_index_resize" "logs" {
+  type             = "shrink"
+  source_index     = elasticstack_elasticsearch_index.logs.name
+  target_index     = "logs-2023.03-shrunk"
+  number_of_shards = 1
+  move_aliases     = true
+
+  settings = jsonencode({
+    "index.number_of_replicas" = 1
+    "index.codec"              = "best_compression"
+  })
+}
@@ -0,0 +1,19 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+// the indices created by the index lifecycle rollover
+resource "elasticstack_elasticsearch_index_settings" "logs" {
+  index = "logs-*"
+
+  setting {
+    name  = "index.number_of_replicas"
+    value = "1"
+  }
+  setting {
+    name  = "index.refresh_interval"
+    value = "30s"
+  }
+
+  reset_on_destroy = true
+}
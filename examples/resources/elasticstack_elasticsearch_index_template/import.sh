@@ -1 +1,3 @@
+# The cluster UUID can be omitted, the UUID of the connected cluster is used then
 terraform import elasticstack_elasticsearch_index_template.my_template <cluster_uuid>/<template_name>
+terraform import elasticstack_elasticsearch_index_template.my_template <template_name>
@@ -1 +1,3 @@
+# The cluster UUID can be omitted, the UUID of the connected cluster is used then
 terraform import elasticstack_elasticsearch_ingest_pipeline.my_ingest_pipeline <cluster_uuid>/<ingest pipeline name>
+terraform import elasticstack_elasticsearch_ingest_pipeline.my_ingest_pipeline <ingest pipeline name>
@@ -1 +1,3 @@
+# The cluster UUID can be omitted, the UUID of the connected cluster is used then
 terraform import elasticstack_elasticsearch_logstash_pipeline.my_pipeline <cluster_uuid>/<pipeline ID>
+terraform import elasticstack_elasticsearch_logstash_pipeline.my_pipeline <pipeline ID>
@@ -1 +1,3 @@
+# The cluster UUID can be omitted, the UUID of the connected cluster is used then
 terraform import elasticstack_elasticsearch_script.my_script <cluster_uuid>/<script id>
+terraform import elasticstack_elasticsearch_script.my_script <script id>
@@ -0,0 +1,33 @@
+resource "elasticstack_elasticsearch_security_api_key" "beats" {
+  name = "Beats API key"
+
+  role_descriptors = jsonencode({
+    beats-writer = {
+      cluster = ["monitor", "read_ilm"],
+      indices = [
+        {
+          names      = ["logs-*", "metrics-*"],
+          privileges = ["create_doc", "view_index_metadata"]
+        }
+      ]
+    }
+  })
+
+  rotation {
+    # Rotate the API key every 90 days
+    rotate_after = "2160h"
+
+    # Keep the previous API key valid for a week after the rotation
+    overlap = "168h"
+  }
+}
+
+output "beats_api_key" {
+  value     = elasticstack_elasticsearch_security_api_key.beats.encoded
+  sensitive = true
+}
+
+output "beats_previous_api_key" {
+  value     = elasticstack_elasticsearch_security_api_key.beats.previous_encoded
+  sensitive = true
+}
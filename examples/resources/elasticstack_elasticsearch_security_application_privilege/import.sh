@@ -0,0 +1,3 @@
+# The cluster UUID can be omitted, the UUID of the connected cluster is used then
+terraform import elasticstack_elasticsearch_security_application_privilege.read <cluster_uuid>/<application>/<privilege name>
+terraform import elasticstack_elasticsearch_security_application_privilege.read <application>/<privilege name>
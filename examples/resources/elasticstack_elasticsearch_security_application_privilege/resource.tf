@@ -0,0 +1,23 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_application_privilege" "read" {
+  application = "myapp"
+  name        = "read"
+  actions     = ["data:read/*", "action:login"]
+
+  metadata = jsonencode({
+    description = "Read access to myapp"
+  })
+}
+
+resource "elasticstack_elasticsearch_security_role" "myapp_reader" {
+  name = "myapp_reader"
+
+  applications {
+    application = elasticstack_elasticsearch_security_application_privilege.read.application
+    privileges  = [elasticstack_elasticsearch_security_application_privilege.read.name]
+    resources   = ["*"]
+  }
+}
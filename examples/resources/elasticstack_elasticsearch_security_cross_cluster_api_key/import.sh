@@ -0,0 +1,4 @@
+# NOTE: the API key secret is only returned on creation, the api_key and encoded attributes of imported keys are left empty
+terraform import elasticstack_elasticsearch_security_cross_cluster_api_key.remote <cluster_uuid>/<api key ID>
+# The cluster UUID can be omitted, the UUID of the connected cluster is used then
+terraform import elasticstack_elasticsearch_security_cross_cluster_api_key.remote <api key ID>
@@ -0,0 +1,31 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_cross_cluster_api_key" "remote" {
+  name = "Remote cluster API key"
+
+  access {
+    # Allow the remote cluster to search the logs and metrics indices
+    search {
+      names = ["logs-*", "metrics-*"]
+    }
+
+    # Allow the remote cluster to replicate the archive indices
+    replication {
+      names = ["archive-*"]
+    }
+  }
+
+  expiration = "90d"
+
+  metadata = jsonencode({
+    "env" = "production"
+  })
+}
+
+# The encoded API key is configured on the remote cluster, as the cluster.remote.<alias>.credentials secure setting
+output "cross_cluster_api_key" {
+  value     = elasticstack_elasticsearch_security_cross_cluster_api_key.remote.encoded
+  sensitive = true
+}
@@ -1 +1,3 @@
+# The cluster UUID can be omitted, the UUID of the connected cluster is used then
 terraform import elasticstack_elasticsearch_security_role.my_role <cluster_uuid>/<role name>
+terraform import elasticstack_elasticsearch_security_role.my_role <role name>
@@ -0,0 +1,10 @@
+resource "elasticstack_elasticsearch_security_role" "remote_search" {
+  name = "remote_search"
+
+  # Grant access to the indices of the remote clusters connected with API key based security
+  remote_indices {
+    clusters   = ["remote-*"]
+    names      = ["logs-*"]
+    privileges = ["read", "view_index_metadata"]
+  }
+}
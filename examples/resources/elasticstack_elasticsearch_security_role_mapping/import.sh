@@ -1 +1,3 @@
+# The cluster UUID can be omitted, the UUID of the connected cluster is used then
 terraform import elasticstack_elasticsearch_security_role_mapping.my_role_mapping <cluster_uuid>/<role mapping name>
+terraform import elasticstack_elasticsearch_security_role_mapping.my_role_mapping <role mapping name>
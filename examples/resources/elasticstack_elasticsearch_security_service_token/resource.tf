@@ -0,0 +1,13 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_service_token" "fleet_server" {
+  service_account = "elastic/fleet-server"
+  name            = "fleet-server-1"
+}
+
+output "fleet_server_token" {
+  value     = elasticstack_elasticsearch_security_service_token.fleet_server.value
+  sensitive = true
+}
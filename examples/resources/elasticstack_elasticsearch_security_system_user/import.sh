@@ -0,0 +1,3 @@
+terraform import elasticstack_elasticsearch_security_system_user.kibana_system <cluster_uuid>/kibana_system
+# The cluster UUID can be omitted, the UUID of the connected cluster is used then
+terraform import elasticstack_elasticsearch_security_system_user.kibana_system kibana_system
@@ -1 +1,3 @@
+# The cluster UUID can be omitted, the UUID of the connected cluster is used then
 terraform import elasticstack_elasticsearch_security_user.user <cluster_uuid>/elastic
+terraform import elasticstack_elasticsearch_security_user.user elastic
@@ -1 +1,3 @@
+# The cluster UUID can be omitted, the UUID of the connected cluster is used then
 terraform import elasticstack_elasticsearch_tranform.my_new_transform <cluster_uuid>/<transform_name>
+terraform import elasticstack_elasticsearch_tranform.my_new_transform <transform_name>
@@ -1 +1,3 @@
+# The cluster UUID can be omitted, the UUID of the connected cluster is used then
 terraform import elasticstack_elasticsearch_watch.watch_id <cluster_uuid>/<watch ID>
+terraform import elasticstack_elasticsearch_watch.watch_id <watch ID>
@@ -1 +1,3 @@
+# The cluster UUID can be omitted, the UUID of the connected cluster is used then
 terraform import elasticstack_kibana_space.my_space <cluster_uuid>/<space id>
+terraform import elasticstack_kibana_space.my_space <space id>
@@ -6,13 +6,22 @@ import (
 	"os"
 	"testing"
 
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest/fakeserver"
 	"github.com/elastic/terraform-provider-elasticstack/provider"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 )
 
 var Providers map[string]func() (tfprotov5.ProviderServer, error)
 
+// FakeServer is the in-memory Elasticsearch server the acceptance tests run against
+// when ELASTICSTACK_FAKE_SERVER is set, nil otherwise.
+var FakeServer *fakeserver.Server
+
 func init() {
+	if _, ok := os.LookupEnv("ELASTICSTACK_FAKE_SERVER"); ok {
+		startFakeServer()
+	}
+
 	providerServerFactory, err := provider.ProtoV5ProviderServerFactory(context.Background(), "dev")
 	if err != nil {
 		log.Fatal(err)
@@ -44,3 +53,24 @@ func PreCheck(t *testing.T) {
 		t.Fatal("ELASTICSEARCH_USERNAME and ELASTICSEARCH_PASSWORD must be set for acceptance tests to run")
 	}
 }
+
+// startFakeServer starts the fake server and points the connection environment variables to it.
+func startFakeServer() {
+	var opts []fakeserver.Option
+	if v := os.Getenv("ELASTICSTACK_FAKE_SERVER_VERSION"); v != "" {
+		opts = append(opts, fakeserver.WithVersion(v))
+	}
+	FakeServer = fakeserver.New(opts...)
+
+	os.Setenv("ELASTICSEARCH_ENDPOINTS", FakeServer.URL)
+	os.Setenv("KIBANA_ENDPOINT", FakeServer.URL)
+	if _, ok := os.LookupEnv("ELASTICSEARCH_USERNAME"); !ok {
+		os.Setenv("ELASTICSEARCH_USERNAME", "elastic")
+	}
+	if _, ok := os.LookupEnv("ELASTICSEARCH_PASSWORD"); !ok {
+		os.Setenv("ELASTICSEARCH_PASSWORD", "changeme")
+	}
+	os.Unsetenv("ELASTICSEARCH_API_KEY")
+	os.Unsetenv("ELASTICSEARCH_BEARER_TOKEN")
+	os.Unsetenv("ELASTICSEARCH_CLOUD_ID")
+}
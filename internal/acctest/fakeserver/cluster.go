@@ -0,0 +1,405 @@
+package fakeserver
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func (s *Server) registerIlmRoutes() {
+	s.handle("GET", "/_ilm/policy", s.getIlmPolicies)
+	s.handle("GET", "/_ilm/policy/{name}", s.getIlmPolicies)
+	s.handle("PUT", "/_ilm/policy/{name}", s.putIlmPolicy)
+	s.handle("DELETE", "/_ilm/policy/{name}", s.deleteIlmPolicy)
+}
+
+func (s *Server) registerIngestRoutes() {
+	s.handle("GET", "/_ingest/pipeline", s.getObjects(s.pipelines, notFoundEmpty))
+	s.handle("GET", "/_ingest/pipeline/{name}", s.getObjects(s.pipelines, notFoundEmpty))
+	s.handle("PUT", "/_ingest/pipeline/{name}", s.putObject(s.pipelines, acknowledged))
+	s.handle("DELETE", "/_ingest/pipeline/{name}", s.deleteObject(s.pipelines, "resource_not_found_exception", "pipeline [%s] is missing"))
+
+	s.handle("GET", "/_logstash/pipeline", s.getObjects(s.logstashPipelines, notFoundEmpty))
+	s.handle("GET", "/_logstash/pipeline/{name}", s.getObjects(s.logstashPipelines, notFoundEmpty))
+	s.handle("PUT", "/_logstash/pipeline/{name}", s.putObject(s.logstashPipelines, func(w http.ResponseWriter, _ bool) { w.WriteHeader(http.StatusCreated) }))
+	s.handle("DELETE", "/_logstash/pipeline/{name}", s.deleteObject(s.logstashPipelines, "", ""))
+}
+
+func (s *Server) registerSnapshotRoutes() {
+	s.handle("GET", "/_snapshot", s.getObjects(s.repositories, notFoundError("repository_missing_exception", "[%s] missing")))
+	s.handle("GET", "/_snapshot/{name}", s.getObjects(s.repositories, notFoundError("repository_missing_exception", "[%s] missing")))
+	s.handle("PUT,POST", "/_snapshot/{name}", s.putRepository)
+	s.handle("DELETE", "/_snapshot/{name}", s.deleteObject(s.repositories, "repository_missing_exception", "[%s] missing"))
+
+	s.handle("GET", "/_slm/policy", s.getSlmPolicies)
+	s.handle("GET", "/_slm/policy/{name}", s.getSlmPolicies)
+	s.handle("PUT", "/_slm/policy/{name}", s.putSlmPolicy)
+	s.handle("DELETE", "/_slm/policy/{name}", s.deleteSlmPolicy)
+}
+
+func (s *Server) registerClusterRoutes() {
+	s.handle("GET", "/_cluster/settings", s.getClusterSettings)
+	s.handle("PUT", "/_cluster/settings", s.putClusterSettings)
+	s.handle("GET", "/_cluster/health", s.clusterHealth)
+	s.handle("GET", "/_cluster/health/{index}", s.clusterHealth)
+
+	s.handle("GET", "/_scripts/{name}", s.getScript)
+	s.handle("PUT,POST", "/_scripts/{name}", s.putScript)
+	s.handle("PUT,POST", "/_scripts/{name}/{context}", s.putScript)
+	s.handle("DELETE", "/_scripts/{name}", s.deleteObject(s.scripts, "resource_not_found_exception", "stored script [%s] does not exist"))
+}
+
+func (s *Server) registerEnrichRoutes() {
+	s.handle("GET", "/_enrich/policy", s.getEnrichPolicies)
+	s.handle("GET", "/_enrich/policy/{name}", s.getEnrichPolicies)
+	s.handle("PUT", "/_enrich/policy/{name}", s.putEnrichPolicy)
+	s.handle("PUT,POST", "/_enrich/policy/{name}/_execute", s.executeEnrichPolicy)
+	s.handle("DELETE", "/_enrich/policy/{name}", s.deleteObject(s.enrichPolicies, "resource_not_found_exception", "policy [%s] not found"))
+}
+
+// notFound writes the response of a GET request for missing objects.
+type notFound func(w http.ResponseWriter, name string)
+
+func notFoundEmpty(w http.ResponseWriter, _ string) {
+	writeJSON(w, http.StatusNotFound, map[string]interface{}{})
+}
+
+func notFoundError(errType, reason string) notFound {
+	return func(w http.ResponseWriter, name string) {
+		writeError(w, http.StatusNotFound, errType, fmt.Sprintf(reason, name))
+	}
+}
+
+// putResponse writes the response of a PUT request, created tells whether the object is new.
+type putResponse func(w http.ResponseWriter, created bool)
+
+func acknowledged(w http.ResponseWriter, _ bool) {
+	writeAcknowledged(w)
+}
+
+// getObjects returns the stored objects as a map keyed by their name.
+func (s *Server) getObjects(store map[string]map[string]interface{}, onNotFound notFound) handler {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		matched, missing := matchNames(params["name"], keys(store))
+		if len(missing) > 0 {
+			onNotFound(w, missing[0])
+			return
+		}
+		result := map[string]interface{}{}
+		for _, name := range matched {
+			result[name] = copyMap(store[name])
+		}
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+func (s *Server) putObject(store map[string]map[string]interface{}, respond putResponse) handler {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		body, ok := decodeBodyOrFail(w, r)
+		if !ok {
+			return
+		}
+		_, exists := store[params["name"]]
+		store[params["name"]] = body
+		respond(w, !exists)
+	}
+}
+
+func (s *Server) deleteObject(store map[string]map[string]interface{}, errType, reason string) handler {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		name := params["name"]
+		if _, ok := store[name]; !ok {
+			if errType == "" {
+				writeJSON(w, http.StatusNotFound, map[string]interface{}{})
+				return
+			}
+			writeError(w, http.StatusNotFound, errType, fmt.Sprintf(reason, name))
+			return
+		}
+		delete(store, name)
+		writeAcknowledged(w)
+	}
+}
+
+func (s *Server) getIlmPolicies(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	matched, missing := matchNames(params["name"], keys(s.ilmPolicies))
+	if len(missing) > 0 {
+		writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("Lifecycle policy not found: %s", missing[0]))
+		return
+	}
+	result := map[string]interface{}{}
+	for _, name := range matched {
+		policy := s.ilmPolicies[name]
+		result[name] = map[string]interface{}{
+			"version":       policy.Version,
+			"modified_date": policy.Modified.UTC().Format("2006-01-02T15:04:05.000Z"),
+			"policy":        copyMap(policy.Body),
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) putIlmPolicy(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	policy, ok := body["policy"].(map[string]interface{})
+	if !ok {
+		writeError(w, http.StatusBadRequest, "x_content_parse_exception", "[put_lifecycle_request] policy is missing")
+		return
+	}
+	if phases, ok := policy["phases"].(map[string]interface{}); ok {
+		for _, p := range phases {
+			phase, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			// Elasticsearch fills the defaults of each phase
+			if _, ok := phase["min_age"]; !ok {
+				phase["min_age"] = "0ms"
+			}
+			if _, ok := phase["actions"]; !ok {
+				phase["actions"] = map[string]interface{}{}
+			}
+		}
+	}
+	s.ilmPolicies[params["name"]] = s.nextVersion(s.ilmPolicies[params["name"]], policy)
+	writeAcknowledged(w)
+}
+
+func (s *Server) deleteIlmPolicy(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	name := params["name"]
+	if _, ok := s.ilmPolicies[name]; !ok {
+		writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("Lifecycle policy not found: %s", name))
+		return
+	}
+	for indexName, idx := range s.indices {
+		if idx.settings["index.lifecycle.name"] == name {
+			writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("Cannot delete policy [%s]. It is in use by one or more indices: [%s]", name, indexName))
+			return
+		}
+	}
+	delete(s.ilmPolicies, name)
+	writeAcknowledged(w)
+}
+
+func (s *Server) nextVersion(previous *versioned, body map[string]interface{}) *versioned {
+	version := 1
+	if previous != nil {
+		version = previous.Version + 1
+	}
+	return &versioned{Version: version, Modified: time.Now(), Body: body}
+}
+
+func (s *Server) putRepository(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	repoType, _ := body["type"].(string)
+	settings, _ := body["settings"].(map[string]interface{})
+	switch repoType {
+	case "fs":
+		if _, ok := settings["location"]; !ok {
+			writeError(w, http.StatusInternalServerError, "repository_exception", fmt.Sprintf("[%s] missing location", params["name"]))
+			return
+		}
+	case "url":
+		if _, ok := settings["url"]; !ok {
+			writeError(w, http.StatusInternalServerError, "repository_exception", fmt.Sprintf("[%s] missing url", params["name"]))
+			return
+		}
+	case "":
+		writeError(w, http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: type is missing;")
+		return
+	}
+
+	// the repository settings are returned as strings
+	repository := map[string]interface{}{"type": repoType, "settings": map[string]interface{}{}}
+	for k, v := range flattenSettings(settings, "") {
+		repository["settings"].(map[string]interface{})[k] = v
+	}
+	s.repositories[params["name"]] = repository
+	writeAcknowledged(w)
+}
+
+func (s *Server) getSlmPolicies(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	matched, missing := matchNames(params["name"], keys(s.slmPolicies))
+	if len(missing) > 0 {
+		writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("snapshot lifecycle policy or policies [%s] not found, no policies are configured", missing[0]))
+		return
+	}
+	result := map[string]interface{}{}
+	for _, name := range matched {
+		policy := s.slmPolicies[name]
+		result[name] = map[string]interface{}{
+			"version":              policy.Version,
+			"modified_date_millis": policy.Modified.UnixNano() / int64(time.Millisecond),
+			"policy":               copyMap(policy.Body),
+			"stats":                map[string]interface{}{"policy": name},
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) putSlmPolicy(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	repository, _ := body["repository"].(string)
+	if _, ok := s.repositories[repository]; !ok {
+		writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("no such repository [%s]", repository))
+		return
+	}
+	if _, ok := body["schedule"].(string); !ok {
+		writeError(w, http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: invalid schedule: schedule must not be empty;")
+		return
+	}
+	s.slmPolicies[params["name"]] = s.nextVersion(s.slmPolicies[params["name"]], body)
+	writeAcknowledged(w)
+}
+
+func (s *Server) deleteSlmPolicy(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	name := params["name"]
+	if _, ok := s.slmPolicies[name]; !ok {
+		writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("snapshot lifecycle policy not found: %s", name))
+		return
+	}
+	delete(s.slmPolicies, name)
+	writeAcknowledged(w)
+}
+
+func (s *Server) getClusterSettings(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	flat := queryBool(r, "flat_settings", false)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"persistent": settingsResponse(s.clusterSettings["persistent"], flat),
+		"transient":  settingsResponse(s.clusterSettings["transient"], flat),
+	})
+}
+
+func (s *Server) putClusterSettings(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	response := map[string]interface{}{"acknowledged": true}
+	for _, category := range []string{"persistent", "transient"} {
+		settings, _ := body[category].(map[string]interface{})
+		updated := map[string]interface{}{}
+		for k, v := range flattenSettings(settings, "") {
+			if v == nil {
+				delete(s.clusterSettings[category], k)
+				continue
+			}
+			s.clusterSettings[category][k] = v
+			updated[k] = v
+		}
+		response[category] = unflattenSettings(updated)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) clusterHealth(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	names, ok := s.resolveIndicesOrFail(w, r, params["index"])
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"cluster_name":          "fake-cluster",
+		"status":                "green",
+		"timed_out":             false,
+		"number_of_nodes":       1,
+		"number_of_data_nodes":  1,
+		"active_primary_shards": len(names),
+		"active_shards":         len(names),
+		"relocating_shards":     0,
+		"initializing_shards":   0,
+		"unassigned_shards":     0,
+	})
+}
+
+func (s *Server) getScript(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	name := params["name"]
+	script, ok := s.scripts[name]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"_id": name, "found": false})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"_id": name, "found": true, "script": copyMap(script)})
+}
+
+func (s *Server) putScript(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	script, ok := body["script"].(map[string]interface{})
+	if !ok {
+		writeError(w, http.StatusBadRequest, "illegal_argument_exception", "must specify a script")
+		return
+	}
+	lang, _ := script["lang"].(string)
+	if !containsString([]string{"painless", "expression", "mustache"}, lang) {
+		writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("unable to put stored script with unsupported lang [%s]", lang))
+		return
+	}
+
+	// search templates can be defined as objects, they are stored as strings
+	source := script["source"]
+	if _, ok := source.(string); !ok {
+		source = compactJSON(source)
+	}
+	s.scripts[params["name"]] = map[string]interface{}{"lang": lang, "source": source}
+	writeAcknowledged(w)
+}
+
+func (s *Server) getEnrichPolicies(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	matched, _ := matchNames(params["name"], keys(s.enrichPolicies))
+	policies := []interface{}{}
+	for _, name := range matched {
+		policies = append(policies, map[string]interface{}{"config": copyMap(s.enrichPolicies[name])})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"policies": policies})
+}
+
+func (s *Server) putEnrichPolicy(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	name := params["name"]
+	if _, ok := s.enrichPolicies[name]; ok {
+		writeError(w, http.StatusBadRequest, "resource_already_exists_exception", fmt.Sprintf("policy [%s] already exists", name))
+		return
+	}
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	if len(body) != 1 {
+		writeError(w, http.StatusBadRequest, "x_content_parse_exception", "expected a single policy type")
+		return
+	}
+	for policyType, def := range body {
+		if !containsString([]string{"match", "range", "geo_match"}, policyType) {
+			writeError(w, http.StatusBadRequest, "x_content_parse_exception", fmt.Sprintf("unsupported policy type [%s]", policyType))
+			return
+		}
+		policy, _ := def.(map[string]interface{})
+		for _, indexName := range stringOrList(policy["indices"]) {
+			if _, missing := s.resolveIndices(indexName); len(missing) > 0 {
+				writeIndexNotFound(w, indexName)
+				return
+			}
+		}
+		policy["name"] = name
+	}
+	s.enrichPolicies[name] = body
+	writeAcknowledged(w)
+}
+
+func (s *Server) executeEnrichPolicy(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if _, ok := s.enrichPolicies[params["name"]]; !ok {
+		writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("policy [%s] does not exist", params["name"]))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"status": map[string]interface{}{"phase": "COMPLETE"}})
+}
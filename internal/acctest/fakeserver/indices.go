@@ -0,0 +1,1091 @@
+package fakeserver
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type index struct {
+	settings   map[string]interface{}
+	mappings   map[string]interface{}
+	aliases    map[string]map[string]interface{}
+	docs       []map[string]interface{}
+	closed     bool
+	dataStream string
+}
+
+// staticIndexSettings can only be updated on closed indices.
+var staticIndexSettings = []string{
+	"index.codec",
+	"index.routing_partition_size",
+	"index.load_fixed_bitset_filters_eagerly",
+	"index.shard.check_on_startup",
+	"index.sort.*",
+	"index.analysis.*",
+	"index.mapping.coerce",
+	"index.soft_deletes.enabled",
+}
+
+// finalIndexSettings can't be updated after the index creation.
+var finalIndexSettings = []string{
+	"index.number_of_shards",
+	"index.uuid",
+	"index.creation_date",
+	"index.provided_name",
+	"index.version.created",
+}
+
+func (s *Server) registerIndexRoutes() {
+	s.handle("GET", "/_data_stream", s.getDataStreams)
+	s.handle("GET", "/_data_stream/{name}", s.getDataStreams)
+	s.handle("PUT", "/_data_stream/{name}", s.putDataStream)
+	s.handle("DELETE", "/_data_stream/{name}", s.deleteDataStream)
+
+	s.handle("POST", "/_aliases", s.updateAliases)
+	s.handle("GET", "/_alias", s.getAliases)
+	s.handle("GET", "/_alias/{name}", s.getAliases)
+
+	s.handle("GET", "/_cat/indices", s.catIndices)
+	s.handle("GET", "/_cat/indices/{index}", s.catIndices)
+	s.handle("GET", "/_cat/shards/{index}", s.catShards)
+
+	s.handle("POST", "/_reindex", s.reindex)
+	s.handle("GET", "/_tasks/{id}", s.getTask)
+	s.handle("POST", "/_tasks/{id}/_cancel", s.cancelTask)
+}
+
+func (s *Server) registerIndexNameRoutes() {
+	s.handle("PUT", "/{index}", s.createIndex)
+	s.handle("GET", "/{index}", s.getIndex)
+	s.handle("HEAD", "/{index}", s.indexExists)
+	s.handle("DELETE", "/{index}", s.deleteIndex)
+
+	s.handle("POST", "/{index}/_doc", s.indexDocument)
+	for _, resizeType := range []string{"shrink", "split", "clone"} {
+		s.handle("PUT,POST", "/{index}/_"+resizeType+"/{target}", s.resizeIndex(resizeType))
+	}
+	s.handle("GET,POST", "/{index}/_count", s.countDocuments)
+
+	s.handle("POST", "/{index}/_open", s.openCloseIndex(false))
+	s.handle("POST", "/{index}/_close", s.openCloseIndex(true))
+
+	s.handle("GET", "/{index}/_settings", s.getIndexSettings)
+	s.handle("PUT", "/{index}/_settings", s.putIndexSettings)
+	s.handle("GET", "/{index}/_mapping", s.getIndexMappings)
+	s.handle("PUT,POST", "/{index}/_mapping", s.putIndexMappings)
+
+	s.handle("GET", "/{index}/_alias", s.getAliases)
+	s.handle("GET", "/{index}/_alias/{name}", s.getAliases)
+	for _, p := range []string{"_alias", "_aliases"} {
+		s.handle("PUT,POST", "/{index}/"+p+"/{name}", s.putAlias)
+		s.handle("DELETE", "/{index}/"+p+"/{name}", s.deleteAlias)
+	}
+}
+
+// resolveIndices resolves an expression of index names, aliases, data streams and wildcards.
+func (s *Server) resolveIndices(expr string) (matched []string, missing []string) {
+	return s.resolveIndicesExpanding(expr, "")
+}
+
+// resolveIndicesExpanding resolves an expression of index names, aliases, data streams and wildcards, the
+// wildcards matching the indices of the given comma separated expand_wildcards states. The wildcards match
+// the open and closed indices when no state is given.
+func (s *Server) resolveIndicesExpanding(expr string, expandWildcards string) (matched []string, missing []string) {
+	states := map[string]bool{"open": true, "closed": true}
+	if expandWildcards != "" {
+		states = map[string]bool{}
+		for _, state := range strings.Split(expandWildcards, ",") {
+			if state == "all" {
+				states["open"], states["closed"], states["hidden"] = true, true, true
+			}
+			states[state] = true
+		}
+	}
+	expanded := func(name string) bool {
+		idx := s.indices[name]
+		if (strings.HasPrefix(name, ".") || idx.settings["index.hidden"] == "true") && !states["hidden"] {
+			return false
+		}
+		if idx.closed {
+			return states["closed"]
+		}
+		return states["open"]
+	}
+
+	seen := map[string]bool{}
+	add := func(names ...string) {
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				matched = append(matched, name)
+			}
+		}
+	}
+
+	for _, e := range strings.Split(expr, ",") {
+		if e == "" || e == "_all" {
+			e = "*"
+		}
+		found := false
+		for _, name := range keys(s.indices) {
+			if ok, _ := path.Match(e, name); ok {
+				if strings.Contains(e, "*") && !expanded(name) {
+					continue
+				}
+				found = true
+				add(name)
+			}
+		}
+		for _, name := range keys(s.indices) {
+			for alias := range s.indices[name].aliases {
+				if ok, _ := path.Match(e, alias); ok {
+					found = true
+					add(name)
+				}
+			}
+		}
+		for _, ds := range keys(s.dataStreams) {
+			if ok, _ := path.Match(e, ds); ok {
+				found = true
+				for _, i := range s.dataStreams[ds]["indices"].([]interface{}) {
+					add(i.(map[string]interface{})["index_name"].(string))
+				}
+			}
+		}
+		if !found && !strings.Contains(e, "*") {
+			missing = append(missing, e)
+		}
+	}
+	sort.Strings(matched)
+	return matched, missing
+}
+
+// resolveIndicesOrFail resolves the indices of the request, writing an error response if a concrete index is missing.
+func (s *Server) resolveIndicesOrFail(w http.ResponseWriter, r *http.Request, expr string) ([]string, bool) {
+	matched, missing := s.resolveIndicesExpanding(expr, r.URL.Query().Get("expand_wildcards"))
+	if len(missing) > 0 && !queryBool(r, "ignore_unavailable", false) {
+		writeIndexNotFound(w, missing[0])
+		return nil, false
+	}
+	return matched, true
+}
+
+func writeIndexNotFound(w http.ResponseWriter, name string) {
+	writeError(w, http.StatusNotFound, "index_not_found_exception", fmt.Sprintf("no such index [%s]", name))
+}
+
+func (s *Server) createIndex(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	name := params["index"]
+	if name != strings.ToLower(name) || strings.ContainsAny(name, `\/*?"<>| ,#:`) {
+		writeError(w, http.StatusBadRequest, "invalid_index_name_exception", fmt.Sprintf("Invalid index name [%s]", name))
+		return
+	}
+	if _, ok := s.indices[name]; ok {
+		writeError(w, http.StatusBadRequest, "resource_already_exists_exception", fmt.Sprintf("index [%s/%s] already exists", name, s.indices[name].settings["index.uuid"]))
+		return
+	}
+	if _, ok := s.dataStreams[name]; ok {
+		writeError(w, http.StatusBadRequest, "resource_already_exists_exception", fmt.Sprintf("data stream [%s] already exists", name))
+		return
+	}
+
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+
+	idx := s.newIndex(name)
+	if settings, ok := body["settings"].(map[string]interface{}); ok {
+		for k, v := range normalizeSettings(settings) {
+			if v != nil {
+				idx.settings[k] = v
+			}
+		}
+	}
+	if mappings, ok := body["mappings"].(map[string]interface{}); ok {
+		mergeMaps(idx.mappings, mappings)
+	}
+	if aliases, ok := body["aliases"].(map[string]interface{}); ok {
+		for alias, def := range aliases {
+			definition, _ := def.(map[string]interface{})
+			idx.aliases[alias] = normalizeAlias(definition)
+		}
+	}
+	s.indices[name] = idx
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"acknowledged":        true,
+		"shards_acknowledged": true,
+		"index":               name,
+	})
+}
+
+// newIndex returns an index with the settings of the matching index template, if any.
+func (s *Server) newIndex(name string) *index {
+	idx := &index{
+		settings: map[string]interface{}{},
+		mappings: map[string]interface{}{},
+		aliases:  map[string]map[string]interface{}{},
+	}
+
+	if templateName, template := s.matchingIndexTemplate(name); template != nil {
+		composed := s.composeTemplate(templateName, template)
+		if settings, ok := composed["settings"].(map[string]interface{}); ok {
+			for k, v := range normalizeSettings(settings) {
+				idx.settings[k] = v
+			}
+		}
+		if mappings, ok := composed["mappings"].(map[string]interface{}); ok {
+			idx.mappings = mappings
+		}
+		if aliases, ok := composed["aliases"].(map[string]interface{}); ok {
+			for alias, def := range aliases {
+				definition, _ := def.(map[string]interface{})
+				idx.aliases[alias] = normalizeAlias(definition)
+			}
+		}
+	}
+
+	defaults := map[string]interface{}{
+		"index.number_of_shards":                            "1",
+		"index.number_of_replicas":                          "1",
+		"index.uuid":                                        randomID()[:22],
+		"index.creation_date":                               strconv.FormatInt(nowMillis(), 10),
+		"index.provided_name":                               name,
+		"index.version.created":                             "8060099",
+		"index.routing.allocation.include._tier_preference": "data_content",
+	}
+	for k, v := range defaults {
+		if _, ok := idx.settings[k]; !ok {
+			idx.settings[k] = v
+		}
+	}
+	return idx
+}
+
+func (s *Server) indexExists(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if _, missing := s.resolveIndices(params["index"]); len(missing) > 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getIndex(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	names, ok := s.resolveIndicesOrFail(w, r, params["index"])
+	if !ok {
+		return
+	}
+
+	flat := queryBool(r, "flat_settings", false)
+	result := map[string]interface{}{}
+	for _, name := range names {
+		idx := s.indices[name]
+		entry := map[string]interface{}{
+			"aliases":  aliasesResponse(idx.aliases),
+			"mappings": copyMap(idx.mappings),
+			"settings": settingsResponse(idx.settings, flat),
+		}
+		if idx.dataStream != "" {
+			entry["data_stream"] = idx.dataStream
+		}
+		result[name] = entry
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) deleteIndex(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	names, ok := s.resolveIndicesOrFail(w, r, params["index"])
+	if !ok {
+		return
+	}
+	for _, name := range names {
+		if ds := s.indices[name].dataStream; ds != "" {
+			writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("index [%s] is the write index for data stream [%s] and cannot be deleted", name, ds))
+			return
+		}
+	}
+	for _, name := range names {
+		delete(s.indices, name)
+	}
+	writeAcknowledged(w)
+}
+
+func (s *Server) openCloseIndex(closed bool) handler {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		names, ok := s.resolveIndicesOrFail(w, r, params["index"])
+		if !ok {
+			return
+		}
+		indices := map[string]interface{}{}
+		for _, name := range names {
+			s.indices[name].closed = closed
+			indices[name] = map[string]interface{}{"closed": closed}
+		}
+		response := map[string]interface{}{"acknowledged": true, "shards_acknowledged": true}
+		if closed {
+			response["indices"] = indices
+		}
+		writeJSON(w, http.StatusOK, response)
+	}
+}
+
+func (s *Server) getIndexSettings(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	names, ok := s.resolveIndicesOrFail(w, r, params["index"])
+	if !ok {
+		return
+	}
+	flat := queryBool(r, "flat_settings", false)
+	result := map[string]interface{}{}
+	for _, name := range names {
+		result[name] = map[string]interface{}{"settings": settingsResponse(s.indices[name].settings, flat)}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) putIndexSettings(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	names, ok := s.resolveIndicesOrFail(w, r, params["index"])
+	if !ok {
+		return
+	}
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	if settings, ok := body["settings"].(map[string]interface{}); ok && len(body) == 1 {
+		body = settings
+	}
+	settings := normalizeSettings(body)
+
+	for k := range settings {
+		if matchesAny(k, finalIndexSettings) {
+			writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("final %s setting [%s], not updateable", names[0], k))
+			return
+		}
+		for _, name := range names {
+			if !s.indices[name].closed && matchesAny(k, staticIndexSettings) {
+				writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("Can't update non dynamic settings [[%s]] for open indices [[%s]]", k, name))
+				return
+			}
+		}
+	}
+
+	for _, name := range names {
+		idx := s.indices[name]
+		// the settings are reset, optionally matching a wildcard, before the others are set
+		for k, v := range settings {
+			switch {
+			case v != nil:
+				continue
+			case k == "index.number_of_replicas":
+				idx.settings[k] = "1"
+			case strings.HasSuffix(k, "*"):
+				for existing := range idx.settings {
+					if strings.HasPrefix(existing, strings.TrimSuffix(k, "*")) {
+						delete(idx.settings, existing)
+					}
+				}
+			default:
+				delete(idx.settings, k)
+			}
+		}
+		for k, v := range settings {
+			if v != nil {
+				idx.settings[k] = v
+			}
+		}
+	}
+	writeAcknowledged(w)
+}
+
+func (s *Server) getIndexMappings(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	names, ok := s.resolveIndicesOrFail(w, r, params["index"])
+	if !ok {
+		return
+	}
+	result := map[string]interface{}{}
+	for _, name := range names {
+		result[name] = map[string]interface{}{"mappings": copyMap(s.indices[name].mappings)}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) putIndexMappings(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	names, ok := s.resolveIndicesOrFail(w, r, params["index"])
+	if !ok {
+		return
+	}
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+
+	for _, name := range names {
+		if err := checkMappingUpdate(s.indices[name].mappings, body, ""); err != "" {
+			writeError(w, http.StatusBadRequest, "illegal_argument_exception", err)
+			return
+		}
+	}
+	for _, name := range names {
+		mappings := s.indices[name].mappings
+		mergeMaps(mappings, copyMap(body))
+		// runtime fields are removed by setting them to null
+		if runtime, ok := mappings["runtime"].(map[string]interface{}); ok {
+			for field, def := range runtime {
+				if def == nil {
+					delete(runtime, field)
+				}
+			}
+			if len(runtime) == 0 {
+				delete(mappings, "runtime")
+			}
+		}
+	}
+	writeAcknowledged(w)
+}
+
+// checkMappingUpdate rejects changing the type of an existing field, as Elasticsearch does.
+func checkMappingUpdate(existing, update map[string]interface{}, prefix string) string {
+	oldProps, _ := existing["properties"].(map[string]interface{})
+	newProps, _ := update["properties"].(map[string]interface{})
+	for field, def := range newProps {
+		newField, _ := def.(map[string]interface{})
+		oldField, ok := oldProps[field].(map[string]interface{})
+		if !ok || newField == nil {
+			continue
+		}
+		oldType, newType := oldField["type"], newField["type"]
+		if oldType == nil && oldField["properties"] != nil {
+			oldType = "object"
+		}
+		if newType == nil && newField["properties"] != nil {
+			newType = "object"
+		}
+		if oldType != nil && newType != nil && oldType != newType {
+			return fmt.Sprintf("mapper [%s%s] cannot be changed from type [%v] to [%v]", prefix, field, oldType, newType)
+		}
+		if err := checkMappingUpdate(oldField, newField, prefix+field+"."); err != "" {
+			return err
+		}
+	}
+	return ""
+}
+
+func (s *Server) getAliases(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	indexExpr := params["index"]
+	names, ok := s.resolveIndicesOrFail(w, r, indexExpr)
+	if !ok {
+		return
+	}
+
+	result := map[string]interface{}{}
+	found := false
+	for _, name := range names {
+		idx := s.indices[name]
+		aliases := map[string]map[string]interface{}{}
+		matched, _ := matchNames(params["name"], keys(idx.aliases))
+		for _, alias := range matched {
+			aliases[alias] = idx.aliases[alias]
+			found = true
+		}
+		if len(aliases) > 0 || (params["name"] == "" && indexExpr != "") {
+			result[name] = map[string]interface{}{"aliases": aliasesResponse(aliases)}
+		}
+	}
+	if params["name"] != "" && !found && !strings.Contains(params["name"], "*") {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{
+			"error":  fmt.Sprintf("alias [%s] missing", params["name"]),
+			"status": http.StatusNotFound,
+		})
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) putAlias(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	names, ok := s.resolveIndicesOrFail(w, r, params["index"])
+	if !ok {
+		return
+	}
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	for _, name := range names {
+		s.indices[name].aliases[params["name"]] = normalizeAlias(body)
+	}
+	writeAcknowledged(w)
+}
+
+func (s *Server) deleteAlias(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	names, ok := s.resolveIndicesOrFail(w, r, params["index"])
+	if !ok {
+		return
+	}
+	deleted := false
+	for _, name := range names {
+		idx := s.indices[name]
+		matched, _ := matchNames(params["name"], keys(idx.aliases))
+		for _, alias := range matched {
+			delete(idx.aliases, alias)
+			deleted = true
+		}
+	}
+	if !deleted {
+		writeError(w, http.StatusNotFound, "aliases_not_found_exception", fmt.Sprintf("aliases [%s] missing", params["name"]))
+		return
+	}
+	writeAcknowledged(w)
+}
+
+// updateAliases applies the actions of the _aliases API atomically: either all of them are applied or none.
+func (s *Server) updateAliases(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	actions, _ := body["actions"].([]interface{})
+
+	type change struct {
+		apply func()
+	}
+	var changes []change
+	for _, a := range actions {
+		action, _ := a.(map[string]interface{})
+		for actionType, def := range action {
+			params, _ := def.(map[string]interface{})
+			indexExprs := stringOrList(params["index"], params["indices"])
+			aliasNames := stringOrList(params["alias"], params["aliases"])
+
+			var names []string
+			for _, expr := range indexExprs {
+				matched, missing := s.resolveIndices(expr)
+				if len(missing) > 0 {
+					writeIndexNotFound(w, missing[0])
+					return
+				}
+				names = append(names, matched...)
+			}
+
+			switch actionType {
+			case "add":
+				definition := copyMap(params)
+				for _, k := range []string{"index", "indices", "alias", "aliases", "must_exist"} {
+					delete(definition, k)
+				}
+				for _, name := range names {
+					for _, alias := range aliasNames {
+						idx, alias := s.indices[name], alias
+						changes = append(changes, change{func() { idx.aliases[alias] = normalizeAlias(definition) }})
+					}
+				}
+			case "remove":
+				for _, name := range names {
+					for _, alias := range aliasNames {
+						idx, alias := s.indices[name], alias
+						if _, ok := idx.aliases[alias]; !ok && params["must_exist"] == true {
+							writeError(w, http.StatusNotFound, "aliases_not_found_exception", fmt.Sprintf("aliases [%s] missing", alias))
+							return
+						}
+						changes = append(changes, change{func() { delete(idx.aliases, alias) }})
+					}
+				}
+			case "remove_index":
+				for _, name := range names {
+					name := name
+					changes = append(changes, change{func() { delete(s.indices, name) }})
+				}
+			default:
+				writeError(w, http.StatusBadRequest, "parsing_exception", fmt.Sprintf("Unknown alias action [%s]", actionType))
+				return
+			}
+		}
+	}
+
+	for _, c := range changes {
+		c.apply()
+	}
+	writeAcknowledged(w)
+}
+
+// indexDocument stores the document, the documents are only counted and copied by reindex.
+func (s *Server) indexDocument(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	names, ok := s.resolveIndicesOrFail(w, r, params["index"])
+	if !ok {
+		return
+	}
+	if len(names) != 1 {
+		writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("no write index is defined for [%s]", params["index"]))
+		return
+	}
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	idx := s.indices[names[0]]
+	if idx.settings["index.blocks.write"] == "true" {
+		writeError(w, http.StatusForbidden, "cluster_block_exception", fmt.Sprintf("index [%s] blocked by: [FORBIDDEN/8/index write (api)];", names[0]))
+		return
+	}
+	idx.docs = append(idx.docs, body)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"_index": names[0],
+		"_id":    randomID(),
+		"result": "created",
+	})
+}
+
+// catShards reports the shards of the indices, the primaries being started on the single node of the cluster
+// and the replicas unassigned.
+func (s *Server) catShards(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	names, ok := s.resolveIndicesOrFail(w, r, params["index"])
+	if !ok {
+		return
+	}
+	result := []interface{}{}
+	for _, name := range names {
+		idx := s.indices[name]
+		shards, _ := strconv.Atoi(settingString(idx.settings["index.number_of_shards"]))
+		replicas, _ := strconv.Atoi(settingString(idx.settings["index.number_of_replicas"]))
+		for shard := 0; shard < shards; shard++ {
+			result = append(result, map[string]interface{}{"index": name, "shard": strconv.Itoa(shard), "prirep": "p", "state": "STARTED", "node": "fake-node"})
+			for i := 0; i < replicas; i++ {
+				result = append(result, map[string]interface{}{"index": name, "shard": strconv.Itoa(shard), "prirep": "r", "state": "UNASSIGNED", "node": nil})
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// resizeIndex shrinks, splits or clones an index, checking the prerequisites as Elasticsearch does.
+func (s *Server) resizeIndex(resizeType string) handler {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		sourceName, targetName := params["index"], params["target"]
+		source, ok := s.indices[sourceName]
+		if !ok {
+			writeIndexNotFound(w, sourceName)
+			return
+		}
+		if _, ok := s.indices[targetName]; ok {
+			writeError(w, http.StatusBadRequest, "resource_already_exists_exception", fmt.Sprintf("index [%s/%s] already exists", targetName, s.indices[targetName].settings["index.uuid"]))
+			return
+		}
+		if source.settings["index.blocks.write"] != "true" {
+			writeError(w, http.StatusBadRequest, "illegal_state_exception", fmt.Sprintf("index %s must be read-only to resize index. use \"index.blocks.write=true\"", sourceName))
+			return
+		}
+		body, ok := decodeBodyOrFail(w, r)
+		if !ok {
+			return
+		}
+		settings := map[string]interface{}{}
+		if s, ok := body["settings"].(map[string]interface{}); ok {
+			settings = normalizeSettings(s)
+		}
+
+		sourceShards, _ := strconv.Atoi(settingString(source.settings["index.number_of_shards"]))
+		targetShards := sourceShards
+		if resizeType == "shrink" {
+			targetShards = 1
+			if source.settings["index.routing.allocation.require._name"] == nil && sourceShards > 1 {
+				writeError(w, http.StatusBadRequest, "illegal_state_exception", fmt.Sprintf("index %s must have all shards allocated on the same node to shrink index", sourceName))
+				return
+			}
+		}
+		if v, ok := settings["index.number_of_shards"]; ok {
+			targetShards, _ = strconv.Atoi(settingString(v))
+		}
+		valid := targetShards > 0
+		switch resizeType {
+		case "shrink":
+			valid = valid && sourceShards%targetShards == 0
+		case "split":
+			valid = valid && targetShards > sourceShards && targetShards%sourceShards == 0
+		case "clone":
+			valid = valid && targetShards == sourceShards
+		}
+		if !valid {
+			writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("the number of target shards [%d] is not compatible with the number of source shards [%d] to %s index", targetShards, sourceShards, resizeType))
+			return
+		}
+
+		target := s.newIndex(targetName)
+		for k, v := range source.settings {
+			if !matchesAny(k, finalIndexSettings) {
+				target.settings[k] = v
+			}
+		}
+		for k, v := range settings {
+			if v == nil {
+				delete(target.settings, k)
+				continue
+			}
+			target.settings[k] = v
+		}
+		target.settings["index.number_of_shards"] = strconv.Itoa(targetShards)
+		target.settings["index.resize.source.name"] = sourceName
+		target.settings["index.resize.source.uuid"] = source.settings["index.uuid"]
+		target.mappings = copyMap(source.mappings)
+		for _, doc := range source.docs {
+			target.docs = append(target.docs, copyMap(doc))
+		}
+		s.indices[targetName] = target
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"acknowledged":        true,
+			"shards_acknowledged": true,
+			"index":               targetName,
+		})
+	}
+}
+
+func (s *Server) countDocuments(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	names, ok := s.resolveIndicesOrFail(w, r, params["index"])
+	if !ok {
+		return
+	}
+	count := 0
+	for _, name := range names {
+		count += len(s.indices[name].docs)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"count": count})
+}
+
+// catIndices reports the indices statistics as JSON, the store size being the size of the documents source.
+// The `h` parameter is ignored, all the columns are returned.
+func (s *Server) catIndices(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	names, ok := s.resolveIndicesOrFail(w, r, params["index"])
+	if !ok {
+		return
+	}
+	result := []interface{}{}
+	for _, name := range names {
+		idx := s.indices[name]
+		health := "green"
+		if idx.settings["index.number_of_replicas"] != "0" {
+			health = "yellow"
+		}
+		entry := map[string]interface{}{
+			"health":        health,
+			"status":        "open",
+			"index":         name,
+			"uuid":          idx.settings["index.uuid"],
+			"pri":           idx.settings["index.number_of_shards"],
+			"rep":           idx.settings["index.number_of_replicas"],
+			"docs.count":    strconv.Itoa(len(idx.docs)),
+			"store.size":    strconv.Itoa(len(compactJSON(idx.docs))),
+			"creation.date": idx.settings["index.creation_date"],
+		}
+		if idx.closed {
+			entry["status"] = "close"
+			entry["docs.count"] = nil
+			entry["store.size"] = nil
+		}
+		result = append(result, entry)
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// reindex copies the documents synchronously, the task of an asynchronous reindex is reported as completed
+// once the task duration of the server has elapsed.
+func (s *Server) reindex(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	source, _ := body["source"].(map[string]interface{})
+	dest, _ := body["dest"].(map[string]interface{})
+	var sources []string
+	for _, expr := range stringOrList(source["index"]) {
+		matched, missing := s.resolveIndices(expr)
+		if len(missing) > 0 {
+			writeIndexNotFound(w, missing[0])
+			return
+		}
+		sources = append(sources, matched...)
+	}
+	destName, _ := dest["index"].(string)
+	destIndex, ok := s.indices[destName]
+	if !ok {
+		writeIndexNotFound(w, destName)
+		return
+	}
+	if pipeline, ok := dest["pipeline"].(string); ok && pipeline != "" {
+		if _, ok := s.pipelines[pipeline]; !ok {
+			writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("pipeline with id [%s] does not exist", pipeline))
+			return
+		}
+	}
+
+	created := 0
+	for _, name := range sources {
+		for _, doc := range s.indices[name].docs {
+			destIndex.docs = append(destIndex.docs, copyMap(doc))
+			created++
+		}
+	}
+	response := map[string]interface{}{
+		"total":    created,
+		"created":  created,
+		"failures": []interface{}{},
+	}
+	if queryBool(r, "wait_for_completion", true) {
+		writeJSON(w, http.StatusOK, response)
+		return
+	}
+	taskID := fmt.Sprintf("%s:%d", randomID(), len(s.tasks)+1)
+	s.tasks[taskID] = map[string]interface{}{
+		"completed": true,
+		"task":      map[string]interface{}{"action": "indices:data/write/reindex"},
+		"response":  response,
+	}
+	if s.taskDuration > 0 {
+		s.runningTasks[taskID] = time.Now().Add(s.taskDuration)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"task": taskID})
+}
+
+func (s *Server) getTask(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	task, ok := s.tasks[params["id"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("task [%s] isn't running and hasn't stored its results", params["id"]))
+		return
+	}
+	if s.isTaskRunning(params["id"]) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"completed": false, "task": task["task"]})
+		return
+	}
+	writeJSON(w, http.StatusOK, copyMap(task))
+}
+
+// cancelTask completes the running task, the response reporting the cancellation as Elasticsearch does.
+func (s *Server) cancelTask(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	id := params["id"]
+	if !s.isTaskRunning(id) {
+		writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("task [%s] is not found", id))
+		return
+	}
+	delete(s.runningTasks, id)
+	response := copyMap(s.tasks[id]["response"].(map[string]interface{}))
+	response["canceled"] = "by user request"
+	s.tasks[id]["response"] = response
+	writeJSON(w, http.StatusOK, map[string]interface{}{"nodes": map[string]interface{}{}})
+}
+
+func (s *Server) isTaskRunning(id string) bool {
+	deadline, ok := s.runningTasks[id]
+	if ok && time.Now().After(deadline) {
+		delete(s.runningTasks, id)
+		return false
+	}
+	return ok
+}
+
+func (s *Server) getDataStreams(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	matched, missing := matchNames(params["name"], keys(s.dataStreams))
+	if len(missing) > 0 {
+		writeIndexNotFound(w, missing[0])
+		return
+	}
+	var dataStreams []interface{}
+	for _, name := range matched {
+		dataStreams = append(dataStreams, copyMap(s.dataStreams[name]))
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data_streams": dataStreams})
+}
+
+func (s *Server) putDataStream(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	name := params["name"]
+	if _, ok := s.dataStreams[name]; ok {
+		writeError(w, http.StatusBadRequest, "resource_already_exists_exception", fmt.Sprintf("data_stream [%s] already exists", name))
+		return
+	}
+	templateName, template := s.matchingIndexTemplate(name)
+	if template == nil || template["data_stream"] == nil {
+		writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("no matching index template found for data stream [%s]", name))
+		return
+	}
+
+	backingIndex := fmt.Sprintf(".ds-%s-%s-000001", name, time.Now().Format("2006.01.02"))
+	idx := s.newIndex(name)
+	idx.settings["index.provided_name"] = backingIndex
+	idx.settings["index.hidden"] = "true"
+	idx.dataStream = name
+	s.indices[backingIndex] = idx
+
+	dataStream := map[string]interface{}{
+		"name":            name,
+		"timestamp_field": map[string]interface{}{"name": "@timestamp"},
+		"indices": []interface{}{
+			map[string]interface{}{"index_name": backingIndex, "index_uuid": idx.settings["index.uuid"]},
+		},
+		"generation": 1,
+		"status":     "GREEN",
+		"template":   templateName,
+		"hidden":     false,
+		"system":     false,
+		"replicated": false,
+	}
+	if meta, ok := template["_meta"]; ok {
+		dataStream["_meta"] = meta
+	}
+	if policy, ok := idx.settings["index.lifecycle.name"]; ok {
+		dataStream["ilm_policy"] = policy
+	}
+	if ds, ok := template["data_stream"].(map[string]interface{}); ok && ds["hidden"] == true {
+		dataStream["hidden"] = true
+	}
+	s.dataStreams[name] = dataStream
+	writeAcknowledged(w)
+}
+
+func (s *Server) deleteDataStream(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	matched, missing := matchNames(params["name"], keys(s.dataStreams))
+	if len(missing) > 0 {
+		writeIndexNotFound(w, missing[0])
+		return
+	}
+	for _, name := range matched {
+		for _, i := range s.dataStreams[name]["indices"].([]interface{}) {
+			delete(s.indices, i.(map[string]interface{})["index_name"].(string))
+		}
+		delete(s.dataStreams, name)
+	}
+	writeAcknowledged(w)
+}
+
+// normalizeSettings flattens the settings and prefixes them with `index.`, converting the values to strings as Elasticsearch does.
+func normalizeSettings(settings map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for k, v := range flattenSettings(settings, "") {
+		if !strings.HasPrefix(k, "index.") {
+			k = "index." + k
+		}
+		result[k] = v
+	}
+	return result
+}
+
+func flattenSettings(settings map[string]interface{}, prefix string) map[string]interface{} {
+	result := map[string]interface{}{}
+	for k, v := range settings {
+		key := prefix + k
+		switch value := v.(type) {
+		case map[string]interface{}:
+			for fk, fv := range flattenSettings(value, key+".") {
+				result[fk] = fv
+			}
+		case []interface{}:
+			var values []interface{}
+			for _, item := range value {
+				values = append(values, settingString(item))
+			}
+			result[key] = values
+		case nil:
+			result[key] = nil
+		default:
+			result[key] = settingString(value)
+		}
+	}
+	return result
+}
+
+func settingString(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// unflattenSettings converts flat settings back to nested objects.
+func unflattenSettings(settings map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, k := range keys(settings) {
+		parts := strings.Split(k, ".")
+		current := result
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := current[part].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				current[part] = next
+			}
+			current = next
+		}
+		current[parts[len(parts)-1]] = settings[k]
+	}
+	return result
+}
+
+func settingsResponse(settings map[string]interface{}, flat bool) map[string]interface{} {
+	if flat {
+		result := map[string]interface{}{}
+		for k, v := range settings {
+			result[k] = v
+		}
+		return result
+	}
+	return unflattenSettings(settings)
+}
+
+func matchesAny(key string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeAlias expands the routing of an alias as Elasticsearch does.
+func normalizeAlias(definition map[string]interface{}) map[string]interface{} {
+	alias := copyMap(definition)
+	if alias == nil {
+		alias = map[string]interface{}{}
+	}
+	if routing, ok := alias["routing"]; ok {
+		delete(alias, "routing")
+		if _, ok := alias["index_routing"]; !ok {
+			alias["index_routing"] = routing
+		}
+		if _, ok := alias["search_routing"]; !ok {
+			alias["search_routing"] = routing
+		}
+	}
+	return alias
+}
+
+func aliasesResponse(aliases map[string]map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for name, def := range aliases {
+		result[name] = copyMap(def)
+	}
+	return result
+}
+
+func stringOrList(values ...interface{}) []string {
+	var result []string
+	for _, v := range values {
+		switch value := v.(type) {
+		case string:
+			result = append(result, value)
+		case []interface{}:
+			for _, item := range value {
+				if s, ok := item.(string); ok {
+					result = append(result, s)
+				}
+			}
+		}
+	}
+	return result
+}
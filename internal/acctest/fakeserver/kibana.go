@@ -0,0 +1,92 @@
+package fakeserver
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// The Kibana endpoints are served on the same address, so the provider can use it for both connections.
+func (s *Server) registerKibanaRoutes() {
+	s.handle("GET", "/api/spaces/space", s.getSpaces)
+	s.handle("POST", "/api/spaces/space", s.createSpace)
+	s.handle("GET", "/api/spaces/space/{id}", s.getSpace)
+	s.handle("PUT", "/api/spaces/space/{id}", s.updateSpace)
+	s.handle("DELETE", "/api/spaces/space/{id}", s.deleteSpace)
+}
+
+func writeKibanaError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"statusCode": status,
+		"error":      http.StatusText(status),
+		"message":    message,
+	})
+}
+
+func (s *Server) getSpaces(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	spaces := []interface{}{}
+	for _, id := range keys(s.spaces) {
+		spaces = append(spaces, copyMap(s.spaces[id]))
+	}
+	writeJSON(w, http.StatusOK, spaces)
+}
+
+func (s *Server) getSpace(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	space, ok := s.spaces[params["id"]]
+	if !ok {
+		writeKibanaError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, copyMap(space))
+}
+
+func (s *Server) createSpace(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	id, _ := body["id"].(string)
+	if id == "" || body["name"] == nil {
+		writeKibanaError(w, http.StatusBadRequest, "[request body.id]: expected value of type [string]")
+		return
+	}
+	if _, ok := s.spaces[id]; ok {
+		writeKibanaError(w, http.StatusConflict, fmt.Sprintf("A space with the identifier %s already exists.", id))
+		return
+	}
+	if _, ok := body["disabledFeatures"]; !ok {
+		body["disabledFeatures"] = []interface{}{}
+	}
+	s.spaces[id] = body
+	writeJSON(w, http.StatusOK, copyMap(body))
+}
+
+func (s *Server) updateSpace(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if _, ok := s.spaces[params["id"]]; !ok {
+		writeKibanaError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	body["id"] = params["id"]
+	if _, ok := body["disabledFeatures"]; !ok {
+		body["disabledFeatures"] = []interface{}{}
+	}
+	s.spaces[params["id"]] = body
+	writeJSON(w, http.StatusOK, copyMap(body))
+}
+
+func (s *Server) deleteSpace(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	space, ok := s.spaces[params["id"]]
+	if !ok {
+		writeKibanaError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	if space["_reserved"] == true {
+		writeKibanaError(w, http.StatusBadRequest, "The default space cannot be deleted because it is reserved.")
+		return
+	}
+	delete(s.spaces, params["id"])
+	w.WriteHeader(http.StatusNoContent)
+}
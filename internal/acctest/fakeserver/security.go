@@ -0,0 +1,833 @@
+package fakeserver
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type user struct {
+	Username string
+	Password string
+	Body     map[string]interface{}
+	Enabled  bool
+	Reserved bool
+}
+
+type apiKey struct {
+	ID          string
+	Name        string
+	Key         string
+	Owner       string
+	Creation    int64
+	Expiration  int64
+	Invalidated bool
+	// Type is either rest or cross_cluster
+	Type string
+	Body map[string]interface{}
+}
+
+var reservedUsers = map[string][]string{
+	"elastic":                {"superuser"},
+	"kibana":                 {"kibana_system"},
+	"kibana_system":          {"kibana_system"},
+	"logstash_system":        {"logstash_system"},
+	"beats_system":           {"beats_system"},
+	"apm_system":             {"apm_system"},
+	"remote_monitoring_user": {"remote_monitoring_collector", "remote_monitoring_agent"},
+}
+
+// serviceAccounts are the built-in service accounts, with their cluster privileges
+var serviceAccounts = map[string][]string{
+	"elastic/enterprise-search-server": {"manage"},
+	"elastic/fleet-server":             {"monitor", "manage_own_api_key"},
+	"elastic/kibana":                   {"monitor", "manage_index_templates", "manage_own_api_key"},
+}
+
+var reservedRoles = []string{"superuser", "kibana_system", "logstash_system", "beats_system", "apm_system", "remote_monitoring_collector", "remote_monitoring_agent", "viewer", "editor"}
+
+func (s *Server) addReservedSecurityEntities() {
+	for name, roles := range reservedUsers {
+		var userRoles []interface{}
+		for _, r := range roles {
+			userRoles = append(userRoles, r)
+		}
+		s.users[name] = &user{
+			Username: name,
+			Body:     map[string]interface{}{"roles": userRoles, "metadata": map[string]interface{}{"_reserved": true}},
+			Enabled:  true,
+			Reserved: true,
+		}
+	}
+	for _, name := range reservedRoles {
+		role := map[string]interface{}{"metadata": map[string]interface{}{"_reserved": true}}
+		if name == "superuser" {
+			role["cluster"] = []interface{}{"all"}
+			role["indices"] = []interface{}{map[string]interface{}{"names": []interface{}{"*"}, "privileges": []interface{}{"all"}, "allow_restricted_indices": true}}
+			role["run_as"] = []interface{}{"*"}
+		}
+		s.roles[name] = role
+	}
+}
+
+func (s *Server) registerSecurityRoutes() {
+	s.handle("GET", "/_security/_authenticate", s.authenticate)
+
+	s.handle("GET", "/_security/user", s.getUsers)
+	s.handle("GET", "/_security/user/{name}", s.getUsers)
+	s.handle("PUT,POST", "/_security/user/{name}", s.putUser)
+	s.handle("DELETE", "/_security/user/{name}", s.deleteUser)
+	s.handle("PUT,POST", "/_security/user/{name}/_enable", s.enableUser(true))
+	s.handle("PUT,POST", "/_security/user/{name}/_disable", s.enableUser(false))
+	s.handle("PUT,POST", "/_security/user/{name}/_password", s.changePassword)
+	s.handle("PUT,POST", "/_security/user/_password", s.changePassword)
+
+	s.handle("GET", "/_security/role", s.getRoles)
+	s.handle("GET", "/_security/role/{name}", s.getRoles)
+	s.handle("PUT,POST", "/_security/role/{name}", s.putRole)
+	s.handle("DELETE", "/_security/role/{name}", s.deleteRole)
+
+	s.handle("GET", "/_security/role_mapping", s.getRoleMappings)
+	s.handle("GET", "/_security/role_mapping/{name}", s.getRoleMappings)
+	s.handle("PUT,POST", "/_security/role_mapping/{name}", s.putRoleMapping)
+	s.handle("DELETE", "/_security/role_mapping/{name}", s.deleteRoleMapping)
+
+	s.handle("GET", "/_security/api_key", s.getApiKeys)
+	s.handle("PUT,POST", "/_security/api_key", s.createApiKey)
+	s.handle("DELETE", "/_security/api_key", s.invalidateApiKeys)
+	s.handle("PUT", "/_security/api_key/{id}", s.updateApiKey)
+	s.handle("POST", "/_security/cross_cluster/api_key", s.createCrossClusterApiKey)
+	s.handle("PUT", "/_security/cross_cluster/api_key/{id}", s.updateCrossClusterApiKey)
+
+	s.handle("GET", "/_security/privilege", s.getPrivileges)
+	s.handle("GET", "/_security/privilege/{application}", s.getPrivileges)
+	s.handle("GET", "/_security/privilege/{application}/{name}", s.getPrivileges)
+	s.handle("PUT,POST", "/_security/privilege", s.putPrivileges)
+	s.handle("DELETE", "/_security/privilege/{application}/{name}", s.deletePrivileges)
+
+	s.handle("GET", "/_security/service", s.getServiceAccounts)
+	s.handle("GET", "/_security/service/{namespace}", s.getServiceAccounts)
+	s.handle("GET", "/_security/service/{namespace}/{service}", s.getServiceAccounts)
+	s.handle("GET", "/_security/service/{namespace}/{service}/credential", s.getServiceCredentials)
+	s.handle("PUT,POST", "/_security/service/{namespace}/{service}/credential/token/{name}", s.createServiceToken)
+	s.handle("DELETE", "/_security/service/{namespace}/{service}/credential/token/{name}", s.deleteServiceToken)
+}
+
+// authenticatedUser returns the user of the request. Requests without credentials are authenticated as elastic.
+func (s *Server) authenticatedUser(r *http.Request) (*user, bool) {
+	auth := r.Header.Get("Authorization")
+	switch {
+	case strings.HasPrefix(auth, "Basic "):
+		username, password, _ := r.BasicAuth()
+		u, ok := s.users[username]
+		if !ok || !u.Enabled || (u.Password != "" && u.Password != password) {
+			return nil, false
+		}
+		return u, true
+	case strings.HasPrefix(auth, "ApiKey "):
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(auth, "ApiKey "))
+		if err != nil {
+			return nil, false
+		}
+		parts := strings.SplitN(string(decoded), ":", 2)
+		key, ok := s.apiKeys[parts[0]]
+		if !ok || len(parts) != 2 || key.Key != parts[1] || key.Invalidated {
+			return nil, false
+		}
+		return s.users[key.Owner], true
+	default:
+		return s.users["elastic"], true
+	}
+}
+
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	u, ok := s.authenticatedUser(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "security_exception", "unable to authenticate user for REST request [/_security/_authenticate]")
+		return
+	}
+	response := s.userResponse(u)
+	realm := map[string]interface{}{"name": "default_native", "type": "native"}
+	if u.Reserved {
+		realm = map[string]interface{}{"name": "reserved", "type": "reserved"}
+	}
+	response["authentication_realm"] = realm
+	response["lookup_realm"] = realm
+	response["authentication_type"] = "realm"
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) userResponse(u *user) map[string]interface{} {
+	response := copyMap(u.Body)
+	delete(response, "password")
+	delete(response, "password_hash")
+	response["username"] = u.Username
+	response["enabled"] = u.Enabled
+	for _, k := range []string{"full_name", "email"} {
+		if _, ok := response[k]; !ok {
+			response[k] = nil
+		}
+	}
+	if _, ok := response["metadata"]; !ok {
+		response["metadata"] = map[string]interface{}{}
+	}
+	if _, ok := response["roles"]; !ok {
+		response["roles"] = []interface{}{}
+	}
+	return response
+}
+
+func (s *Server) getUsers(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	matched, _ := matchNames(params["name"], keys(s.users))
+	if len(matched) == 0 && params["name"] != "" {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{})
+		return
+	}
+	result := map[string]interface{}{}
+	for _, name := range matched {
+		result[name] = s.userResponse(s.users[name])
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) putUser(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	name := params["name"]
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	existing, exists := s.users[name]
+	if exists && existing.Reserved {
+		writeError(w, http.StatusBadRequest, "validation_exception", fmt.Sprintf("Validation Failed: 1: user [%s] is reserved and only the password can be changed;", name))
+		return
+	}
+	password, _ := body["password"].(string)
+	_, hasHash := body["password_hash"]
+	if !exists && password == "" && !hasHash {
+		writeError(w, http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: password must be specified unless you are updating an existing user;")
+		return
+	}
+	if password != "" && len(password) < 6 {
+		writeError(w, http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: passwords must be at least [6] characters long;")
+		return
+	}
+
+	u := &user{Username: name, Body: body, Enabled: true}
+	if enabled, ok := body["enabled"].(bool); ok {
+		u.Enabled = enabled
+	}
+	delete(body, "enabled")
+	switch {
+	case password != "":
+		u.Password = password
+	case hasHash:
+		// the hashes aren't verified, any password is accepted
+		u.Password = ""
+	case exists:
+		u.Password = existing.Password
+	}
+	s.users[name] = u
+	writeJSON(w, http.StatusOK, map[string]interface{}{"created": !exists})
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	name := params["name"]
+	u, ok := s.users[name]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"found": false})
+		return
+	}
+	if u.Reserved {
+		writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("user [%s] is reserved and cannot be deleted", name))
+		return
+	}
+	delete(s.users, name)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"found": true})
+}
+
+func (s *Server) enableUser(enabled bool) handler {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		u, ok := s.users[params["name"]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("user [%s] not found", params["name"]))
+			return
+		}
+		u.Enabled = enabled
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	}
+}
+
+func (s *Server) changePassword(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	name := params["name"]
+	if name == "" {
+		u, ok := s.authenticatedUser(r)
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "security_exception", "unable to authenticate user")
+			return
+		}
+		name = u.Username
+	}
+	u, ok := s.users[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("user [%s] not found", name))
+		return
+	}
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	if password, ok := body["password"].(string); ok {
+		u.Password = password
+	} else if _, ok := body["password_hash"]; ok {
+		u.Password = ""
+	} else {
+		writeError(w, http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: password must be specified;")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+func (s *Server) getRoles(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	matched, _ := matchNames(params["name"], keys(s.roles))
+	if len(matched) == 0 && params["name"] != "" {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{})
+		return
+	}
+	result := map[string]interface{}{}
+	for _, name := range matched {
+		role := copyMap(s.roles[name])
+		for _, k := range []string{"cluster", "indices", "applications", "run_as"} {
+			if _, ok := role[k]; !ok {
+				role[k] = []interface{}{}
+			}
+		}
+		if _, ok := role["metadata"]; !ok {
+			role["metadata"] = map[string]interface{}{}
+		}
+		role["transient_metadata"] = map[string]interface{}{"enabled": true}
+		result[name] = role
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) putRole(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	name := params["name"]
+	if containsString(reservedRoles, name) {
+		writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("role [%s] is reserved and cannot be modified", name))
+		return
+	}
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	if _, ok := body["remote_indices"]; ok && !s.versionAtLeast("8.10.0") {
+		writeError(w, http.StatusBadRequest, "parse_exception", fmt.Sprintf("failed to parse role [%s]. unexpected field [remote_indices]", name))
+		return
+	}
+	_, exists := s.roles[name]
+	s.roles[name] = body
+	writeJSON(w, http.StatusOK, map[string]interface{}{"role": map[string]interface{}{"created": !exists}})
+}
+
+func (s *Server) deleteRole(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	name := params["name"]
+	if containsString(reservedRoles, name) {
+		writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("role [%s] is reserved and cannot be deleted", name))
+		return
+	}
+	if _, ok := s.roles[name]; !ok {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"found": false})
+		return
+	}
+	delete(s.roles, name)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"found": true})
+}
+
+func (s *Server) getRoleMappings(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	matched, _ := matchNames(params["name"], keys(s.roleMappings))
+	if len(matched) == 0 && params["name"] != "" {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{})
+		return
+	}
+	result := map[string]interface{}{}
+	for _, name := range matched {
+		mapping := copyMap(s.roleMappings[name])
+		if _, ok := mapping["metadata"]; !ok {
+			mapping["metadata"] = map[string]interface{}{}
+		}
+		result[name] = mapping
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) putRoleMapping(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	if body["roles"] == nil && body["role_templates"] == nil {
+		writeError(w, http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: a role mapping must have either [roles] or [role_templates];")
+		return
+	}
+	if _, ok := body["enabled"]; !ok {
+		body["enabled"] = true
+	}
+	_, exists := s.roleMappings[params["name"]]
+	s.roleMappings[params["name"]] = body
+	writeJSON(w, http.StatusOK, map[string]interface{}{"role_mapping": map[string]interface{}{"created": !exists}})
+}
+
+func (s *Server) deleteRoleMapping(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	name := params["name"]
+	if _, ok := s.roleMappings[name]; !ok {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"found": false})
+		return
+	}
+	delete(s.roleMappings, name)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"found": true})
+}
+
+func (s *Server) createApiKey(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s.createTypedApiKey(w, r, "rest")
+}
+
+func (s *Server) createCrossClusterApiKey(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	// the cross-cluster API key API is available since 8.10
+	if !s.versionAtLeast("8.10.0") {
+		writeNoHandler(w, r)
+		return
+	}
+	s.createTypedApiKey(w, r, "cross_cluster")
+}
+
+func (s *Server) createTypedApiKey(w http.ResponseWriter, r *http.Request, keyType string) {
+	owner, ok := s.authenticatedUser(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "security_exception", "unable to authenticate user")
+		return
+	}
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	name, _ := body["name"].(string)
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: api key name is required;")
+		return
+	}
+	if keyType == "cross_cluster" && !validCrossClusterAccess(body["access"]) {
+		writeError(w, http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: must specify non-empty access for either [search] or [replication];")
+		return
+	}
+
+	key := &apiKey{
+		ID:       randomID()[:20],
+		Name:     name,
+		Key:      randomID()[:22],
+		Owner:    owner.Username,
+		Creation: nowMillis(),
+		Type:     keyType,
+		Body:     body,
+	}
+	response := map[string]interface{}{"id": key.ID, "name": key.Name, "api_key": key.Key}
+	if expiration, ok := body["expiration"].(string); ok && expiration != "" {
+		d, err := parseElasticDuration(expiration)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "illegal_argument_exception", err.Error())
+			return
+		}
+		key.Expiration = key.Creation + d.Milliseconds()
+		response["expiration"] = key.Expiration
+	}
+	response["encoded"] = base64.StdEncoding.EncodeToString([]byte(key.ID + ":" + key.Key))
+	s.apiKeys[key.ID] = key
+	writeJSON(w, http.StatusOK, response)
+}
+
+func validCrossClusterAccess(access interface{}) bool {
+	a, ok := access.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	search, _ := a["search"].([]interface{})
+	replication, _ := a["replication"].([]interface{})
+	return len(search) > 0 || len(replication) > 0
+}
+
+// crossClusterRoleDescriptor returns the role descriptor derived from the access of a cross-cluster API key.
+func crossClusterRoleDescriptor(access map[string]interface{}) map[string]interface{} {
+	cluster := []interface{}{}
+	indices := []interface{}{}
+	if search, _ := access["search"].([]interface{}); len(search) > 0 {
+		cluster = append(cluster, "cross_cluster_search")
+		for _, entry := range search {
+			index := copyMap(entry.(map[string]interface{}))
+			index["privileges"] = []interface{}{"read", "read_cross_cluster", "view_index_metadata"}
+			if _, ok := index["allow_restricted_indices"]; !ok {
+				index["allow_restricted_indices"] = false
+			}
+			indices = append(indices, index)
+		}
+	}
+	if replication, _ := access["replication"].([]interface{}); len(replication) > 0 {
+		cluster = append(cluster, "cross_cluster_replication")
+		for _, entry := range replication {
+			index := copyMap(entry.(map[string]interface{}))
+			index["privileges"] = []interface{}{"cross_cluster_replication", "cross_cluster_replication_internal"}
+			index["allow_restricted_indices"] = false
+			indices = append(indices, index)
+		}
+	}
+	return map[string]interface{}{"cluster": cluster, "indices": indices, "run_as": []interface{}{}}
+}
+
+func (s *Server) apiKeyResponse(key *apiKey) map[string]interface{} {
+	response := map[string]interface{}{
+		"id":          key.ID,
+		"name":        key.Name,
+		"creation":    key.Creation,
+		"invalidated": key.Invalidated,
+		"username":    key.Owner,
+		"realm":       "reserved",
+		"metadata":    map[string]interface{}{},
+	}
+	if key.Expiration > 0 {
+		response["expiration"] = key.Expiration
+	}
+	if metadata, ok := key.Body["metadata"]; ok {
+		response["metadata"] = metadata
+	}
+	// the role descriptors are returned since 8.5
+	if s.versionAtLeast("8.5.0") {
+		descriptors, ok := key.Body["role_descriptors"]
+		if !ok {
+			descriptors = map[string]interface{}{}
+		}
+		if key.Type == "cross_cluster" {
+			access, _ := key.Body["access"].(map[string]interface{})
+			descriptors = map[string]interface{}{"cross_cluster": crossClusterRoleDescriptor(access)}
+			response["access"] = access
+		}
+		response["role_descriptors"] = descriptors
+	}
+	// the type is returned since 8.10
+	if s.versionAtLeast("8.10.0") {
+		response["type"] = key.Type
+	}
+	return response
+}
+
+func (s *Server) getApiKeys(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	query := r.URL.Query()
+	id, name := query.Get("id"), query.Get("name")
+	owner := queryBool(r, "owner", false)
+	current, _ := s.authenticatedUser(r)
+
+	apiKeys := []interface{}{}
+	for _, keyID := range keys(s.apiKeys) {
+		key := s.apiKeys[keyID]
+		if id != "" && key.ID != id {
+			continue
+		}
+		if name != "" {
+			if matched, _ := matchNames(name, []string{key.Name}); len(matched) == 0 {
+				continue
+			}
+		}
+		if owner && current != nil && key.Owner != current.Username {
+			continue
+		}
+		apiKeys = append(apiKeys, s.apiKeyResponse(key))
+	}
+	if len(apiKeys) == 0 && (id != "" || name != "") {
+		writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("unable to find apikey with id [%s] or name [%s]", id, name))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"api_keys": apiKeys})
+}
+
+// updateApiKey replaces the role descriptors and metadata of an API key, the omitted ones are left unchanged.
+func (s *Server) updateApiKey(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	// the update API key API is available since 8.4
+	if !s.versionAtLeast("8.4.0") {
+		writeNoHandler(w, r)
+		return
+	}
+	s.updateTypedApiKey(w, r, params["id"], "rest", []string{"role_descriptors", "metadata"})
+}
+
+// updateCrossClusterApiKey replaces the access and metadata of a cross-cluster API key.
+func (s *Server) updateCrossClusterApiKey(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if !s.versionAtLeast("8.10.0") {
+		writeNoHandler(w, r)
+		return
+	}
+	s.updateTypedApiKey(w, r, params["id"], "cross_cluster", []string{"access", "metadata"})
+}
+
+func (s *Server) updateTypedApiKey(w http.ResponseWriter, r *http.Request, id string, keyType string, fields []string) {
+	owner, ok := s.authenticatedUser(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "security_exception", "unable to authenticate user")
+		return
+	}
+	key, ok := s.apiKeys[id]
+	if !ok || key.Invalidated || key.Owner != owner.Username {
+		writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("no API key owned by requesting user found for ID [%s]", id))
+		return
+	}
+	if key.Type != keyType {
+		writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("cannot update API key of type [%s] while expected type is [%s]", key.Type, keyType))
+		return
+	}
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	if access, ok := body["access"]; ok && !validCrossClusterAccess(access) {
+		writeError(w, http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: must specify non-empty access for either [search] or [replication];")
+		return
+	}
+
+	updated := false
+	updatedBody := copyMap(key.Body)
+	for _, field := range fields {
+		value, ok := body[field]
+		if !ok {
+			continue
+		}
+		if !reflect.DeepEqual(updatedBody[field], value) {
+			updated = true
+		}
+		updatedBody[field] = value
+	}
+	key.Body = updatedBody
+	writeJSON(w, http.StatusOK, map[string]interface{}{"updated": updated})
+}
+
+func (s *Server) invalidateApiKeys(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	ids := stringOrList(body["ids"], body["id"])
+	names := stringOrList(body["name"])
+
+	invalidated := []interface{}{}
+	previouslyInvalidated := []interface{}{}
+	for _, keyID := range keys(s.apiKeys) {
+		key := s.apiKeys[keyID]
+		if !containsString(ids, key.ID) && !containsString(names, key.Name) {
+			continue
+		}
+		if key.Invalidated {
+			previouslyInvalidated = append(previouslyInvalidated, key.ID)
+			continue
+		}
+		key.Invalidated = true
+		invalidated = append(invalidated, key.ID)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"invalidated_api_keys":            invalidated,
+		"previously_invalidated_api_keys": previouslyInvalidated,
+		"error_count":                     0,
+	})
+}
+
+func (s *Server) getPrivileges(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	result := map[string]interface{}{}
+	for application, privileges := range s.appPrivileges {
+		if params["application"] != "" && params["application"] != application {
+			continue
+		}
+		matched, _ := matchNames(params["name"], keys(privileges))
+		appResult := map[string]interface{}{}
+		for _, name := range matched {
+			appResult[name] = privileges[name]
+		}
+		if len(appResult) > 0 {
+			result[application] = appResult
+		}
+	}
+	if len(result) == 0 && params["application"] != "" {
+		writeJSON(w, http.StatusNotFound, result)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) putPrivileges(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	// validate all the privileges before storing any of them
+	for application, privileges := range body {
+		privilegesMap, ok := privileges.(map[string]interface{})
+		if !ok {
+			writeError(w, http.StatusBadRequest, "parse_exception", fmt.Sprintf("privileges of application [%s] must be an object", application))
+			return
+		}
+		for name, privilege := range privilegesMap {
+			privilegeMap, _ := privilege.(map[string]interface{})
+			actions, _ := privilegeMap["actions"].([]interface{})
+			if len(actions) == 0 {
+				writeError(w, http.StatusBadRequest, "action_request_validation_exception", fmt.Sprintf("Validation Failed: 1: Application privileges must have at least one action [%s];", name))
+				return
+			}
+			for _, action := range actions {
+				if a, _ := action.(string); !strings.ContainsAny(a, "/*:") {
+					writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("action [%v] must contain one of [/*:]", action))
+					return
+				}
+			}
+		}
+	}
+
+	result := map[string]interface{}{}
+	for application, privileges := range body {
+		if s.appPrivileges[application] == nil {
+			s.appPrivileges[application] = map[string]map[string]interface{}{}
+		}
+		appResult := map[string]interface{}{}
+		for name, privilege := range privileges.(map[string]interface{}) {
+			_, exists := s.appPrivileges[application][name]
+			stored := copyMap(privilege.(map[string]interface{}))
+			stored["application"] = application
+			stored["name"] = name
+			if _, ok := stored["metadata"]; !ok {
+				stored["metadata"] = map[string]interface{}{}
+			}
+			s.appPrivileges[application][name] = stored
+			appResult[name] = map[string]interface{}{"created": !exists}
+		}
+		result[application] = appResult
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) deletePrivileges(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	application, name := params["application"], params["name"]
+	_, found := s.appPrivileges[application][name]
+	delete(s.appPrivileges[application], name)
+	status := http.StatusOK
+	if !found {
+		status = http.StatusNotFound
+	}
+	writeJSON(w, status, map[string]interface{}{application: map[string]interface{}{name: map[string]interface{}{"found": found}}})
+}
+
+func (s *Server) getServiceAccounts(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	result := map[string]interface{}{}
+	for name, cluster := range serviceAccounts {
+		namespace, service, _ := strings.Cut(name, "/")
+		if (params["namespace"] != "" && params["namespace"] != namespace) || (params["service"] != "" && params["service"] != service) {
+			continue
+		}
+		var privileges []interface{}
+		for _, p := range cluster {
+			privileges = append(privileges, p)
+		}
+		result[name] = map[string]interface{}{
+			"role_descriptor": map[string]interface{}{
+				"cluster":  privileges,
+				"indices":  []interface{}{},
+				"metadata": map[string]interface{}{},
+			},
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// serviceAccount returns the <namespace>/<service> name of the service account, writing a not found error if it doesn't exist.
+func serviceAccount(w http.ResponseWriter, params map[string]string) (string, bool) {
+	name := params["namespace"] + "/" + params["service"]
+	if _, ok := serviceAccounts[name]; !ok {
+		writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("service account [%s] does not exist", name))
+		return "", false
+	}
+	return name, true
+}
+
+func (s *Server) getServiceCredentials(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	name, ok := serviceAccount(w, params)
+	if !ok {
+		return
+	}
+	tokens := map[string]interface{}{}
+	for tokenName := range s.serviceTokens[name] {
+		tokens[tokenName] = map[string]interface{}{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"service_account": name,
+		"count":           len(tokens),
+		"tokens":          tokens,
+		"nodes_credentials": map[string]interface{}{
+			"_nodes":      map[string]interface{}{"total": 1, "successful": 1, "failed": 0},
+			"file_tokens": map[string]interface{}{},
+		},
+	})
+}
+
+func (s *Server) createServiceToken(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	name, ok := serviceAccount(w, params)
+	if !ok {
+		return
+	}
+	tokenName := params["name"]
+	if _, exists := s.serviceTokens[name][tokenName]; exists {
+		writeError(w, http.StatusConflict, "version_conflict_engine_exception", fmt.Sprintf("[token-%s/%s]: version conflict, document already exists", name, tokenName))
+		return
+	}
+	if s.serviceTokens[name] == nil {
+		s.serviceTokens[name] = map[string]string{}
+	}
+	value := base64.StdEncoding.EncodeToString([]byte(name + "/" + tokenName + ":" + randomID()))
+	s.serviceTokens[name][tokenName] = value
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"created": true,
+		"token":   map[string]interface{}{"name": tokenName, "value": value},
+	})
+}
+
+func (s *Server) deleteServiceToken(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	name, ok := serviceAccount(w, params)
+	if !ok {
+		return
+	}
+	if _, exists := s.serviceTokens[name][params["name"]]; !exists {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"found": false})
+		return
+	}
+	delete(s.serviceTokens[name], params["name"])
+	writeJSON(w, http.StatusOK, map[string]interface{}{"found": true})
+}
+
+// parseElasticDuration parses a duration using the Elasticsearch time units.
+func parseElasticDuration(value string) (time.Duration, error) {
+	units := []struct {
+		suffix string
+		unit   time.Duration
+	}{
+		{"nanos", time.Nanosecond},
+		{"micros", time.Microsecond},
+		{"ms", time.Millisecond},
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+	}
+	for _, u := range units {
+		if strings.HasSuffix(value, u.suffix) {
+			var n int64
+			if _, err := fmt.Sscanf(strings.TrimSuffix(value, u.suffix), "%d", &n); err != nil {
+				return 0, fmt.Errorf("failed to parse setting [expiration] with value [%s] as a time value", value)
+			}
+			return time.Duration(n) * u.unit, nil
+		}
+	}
+	return 0, fmt.Errorf("failed to parse setting [expiration] with value [%s] as a time value: unit is missing or unrecognized", value)
+}
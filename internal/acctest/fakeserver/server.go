@@ -0,0 +1,433 @@
+// Package fakeserver implements an in-memory stand-in for the Elasticsearch REST API,
+// covering the endpoints used by the provider. It allows to run the acceptance tests
+// without a live cluster, see acctest.PreCheck.
+//
+// The server only mimics the behaviour the provider relies on: it doesn't validate
+// most of the request bodies and returns them mostly as they were stored.
+package fakeserver
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-version"
+)
+
+const DefaultVersion = "8.10.0"
+
+type Option func(*Server)
+
+// WithVersion sets the Elasticsearch version reported by the server.
+func WithVersion(v string) Option {
+	return func(s *Server) {
+		s.version = v
+	}
+}
+
+// WithTaskDuration keeps the asynchronous tasks running for the duration, instead of completing them at once.
+func WithTaskDuration(d time.Duration) Option {
+	return func(s *Server) {
+		s.taskDuration = d
+	}
+}
+
+// Server is a fake Elasticsearch cluster, which also serves the Kibana spaces API.
+type Server struct {
+	URL string
+
+	version     string
+	clusterUUID string
+	httpServer  *httptest.Server
+	routes      []route
+
+	mu                 sync.Mutex
+	indices            map[string]*index
+	dataStreams        map[string]map[string]interface{}
+	indexTemplates     map[string]map[string]interface{}
+	componentTemplates map[string]map[string]interface{}
+	ilmPolicies        map[string]*versioned
+	pipelines          map[string]map[string]interface{}
+	logstashPipelines  map[string]map[string]interface{}
+	users              map[string]*user
+	roles              map[string]map[string]interface{}
+	roleMappings       map[string]map[string]interface{}
+	apiKeys            map[string]*apiKey
+	appPrivileges      map[string]map[string]map[string]interface{}
+	serviceTokens      map[string]map[string]string
+	transforms         map[string]*transform
+	watches            map[string]*watch
+	slmPolicies        map[string]*versioned
+	repositories       map[string]map[string]interface{}
+	scripts            map[string]map[string]interface{}
+	clusterSettings    map[string]map[string]interface{}
+	enrichPolicies     map[string]map[string]interface{}
+	spaces             map[string]map[string]interface{}
+	tasks              map[string]map[string]interface{}
+	taskDuration       time.Duration
+	runningTasks       map[string]time.Time
+}
+
+// versioned is a stored object which reports its version and modification date.
+type versioned struct {
+	Version  int
+	Modified time.Time
+	Body     map[string]interface{}
+}
+
+type handler func(w http.ResponseWriter, r *http.Request, params map[string]string)
+
+type route struct {
+	methods  []string
+	segments []string
+	handler  handler
+}
+
+// New starts a new fake server, which must be closed by the caller.
+func New(opts ...Option) *Server {
+	s := &Server{
+		version:            DefaultVersion,
+		clusterUUID:        randomUUID(),
+		indices:            map[string]*index{},
+		dataStreams:        map[string]map[string]interface{}{},
+		indexTemplates:     map[string]map[string]interface{}{},
+		componentTemplates: map[string]map[string]interface{}{},
+		ilmPolicies:        map[string]*versioned{},
+		pipelines:          map[string]map[string]interface{}{},
+		logstashPipelines:  map[string]map[string]interface{}{},
+		users:              map[string]*user{},
+		roles:              map[string]map[string]interface{}{},
+		roleMappings:       map[string]map[string]interface{}{},
+		apiKeys:            map[string]*apiKey{},
+		appPrivileges:      map[string]map[string]map[string]interface{}{},
+		serviceTokens:      map[string]map[string]string{},
+		transforms:         map[string]*transform{},
+		watches:            map[string]*watch{},
+		slmPolicies:        map[string]*versioned{},
+		repositories:       map[string]map[string]interface{}{},
+		scripts:            map[string]map[string]interface{}{},
+		clusterSettings:    map[string]map[string]interface{}{"persistent": {}, "transient": {}},
+		enrichPolicies:     map[string]map[string]interface{}{},
+		spaces:             map[string]map[string]interface{}{},
+		tasks:              map[string]map[string]interface{}{},
+		runningTasks:       map[string]time.Time{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.addReservedSecurityEntities()
+	s.spaces["default"] = map[string]interface{}{"id": "default", "name": "Default", "description": "This is your default space!", "disabledFeatures": []interface{}{}, "_reserved": true}
+
+	s.registerRoutes()
+	s.httpServer = httptest.NewServer(s)
+	s.URL = s.httpServer.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// Version returns the Elasticsearch version reported by the server.
+func (s *Server) Version() string {
+	return s.version
+}
+
+func (s *Server) versionAtLeast(v string) bool {
+	current, err := version.NewVersion(s.version)
+	if err != nil {
+		return true
+	}
+	return current.GreaterThanOrEqual(version.Must(version.NewVersion(v)))
+}
+
+func (s *Server) handle(methods string, pattern string, h handler) {
+	s.routes = append(s.routes, route{
+		methods:  strings.Split(methods, ","),
+		segments: splitPath(pattern),
+		handler:  h,
+	})
+}
+
+func (s *Server) registerRoutes() {
+	s.handle("GET", "/", s.info)
+
+	s.registerIndexRoutes()
+	s.registerTemplateRoutes()
+	s.registerIlmRoutes()
+	s.registerIngestRoutes()
+	s.registerSecurityRoutes()
+	s.registerTransformRoutes()
+	s.registerWatcherRoutes()
+	s.registerSnapshotRoutes()
+	s.registerClusterRoutes()
+	s.registerEnrichRoutes()
+	s.registerKibanaRoutes()
+
+	// must be registered last, since the index names match any path
+	s.registerIndexNameRoutes()
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Elastic-Product", "Elasticsearch")
+
+	// names may contain escaped slashes, so the path is split before being unescaped
+	var segments []string
+	for _, segment := range splitPath(r.URL.EscapedPath()) {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			unescaped = segment
+		}
+		segments = append(segments, unescaped)
+	}
+	methodMismatch := false
+	for _, rt := range s.routes {
+		params, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+		if !containsString(rt.methods, r.Method) {
+			methodMismatch = true
+			continue
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		rt.handler(w, r, params)
+		return
+	}
+
+	if methodMismatch {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]interface{}{
+			"error":  fmt.Sprintf("Incorrect HTTP method for uri [%s] and method [%s]", r.URL.Path, r.Method),
+			"status": http.StatusMethodNotAllowed,
+		})
+		return
+	}
+	writeNoHandler(w, r)
+}
+
+// writeNoHandler writes the response of Elasticsearch to requests for an unknown API.
+func writeNoHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+		"error":  fmt.Sprintf("no handler found for uri [%s] and method [%s]", r.URL.Path, r.Method),
+		"status": http.StatusBadRequest,
+	})
+}
+
+func (rt route) match(segments []string) (map[string]string, bool) {
+	if len(rt.segments) != len(segments) {
+		return nil, false
+	}
+	params := map[string]string{}
+	for i, s := range rt.segments {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			// the API endpoints all start with an underscore, they are never matched as a name
+			if strings.HasPrefix(segments[i], "_") {
+				return nil, false
+			}
+			params[strings.Trim(s, "{}")] = segments[i]
+			continue
+		}
+		if s != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func (s *Server) info(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"name":         "fake-node",
+		"cluster_name": "fake-cluster",
+		"cluster_uuid": s.clusterUUID,
+		"version": map[string]interface{}{
+			"number":                              s.version,
+			"build_flavor":                        "default",
+			"build_type":                          "docker",
+			"build_hash":                          "0000000000000000000000000000000000000000",
+			"build_date":                          "2023-01-01T00:00:00.000000000Z",
+			"build_snapshot":                      false,
+			"lucene_version":                      "9.4.2",
+			"minimum_wire_compatibility_version":  "7.17.0",
+			"minimum_index_compatibility_version": "7.0.0",
+		},
+		"tagline": "You Know, for Search",
+	})
+}
+
+func splitPath(p string) []string {
+	var segments []string
+	for _, s := range strings.Split(p, "/") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+	return segments
+}
+
+func decodeBody(r *http.Request) (map[string]interface{}, error) {
+	body := map[string]interface{}{}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return body, nil
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// decodeBodyOrFail decodes the request body, writing an error response when it isn't valid JSON.
+func decodeBodyOrFail(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	body, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "parse_exception", fmt.Sprintf("request body is invalid: %s", err))
+		return nil, false
+	}
+	return body, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, errType, reason string) {
+	cause := map[string]interface{}{"type": errType, "reason": reason}
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"root_cause": []interface{}{cause},
+			"type":       errType,
+			"reason":     reason,
+		},
+		"status": status,
+	})
+}
+
+func writeAcknowledged(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+}
+
+func randomID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// randomUUID returns a random URL safe base64 UUID, as used by Elasticsearch for the cluster UUID.
+func randomUUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func nowMillis() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
+
+// matchNames returns the names matching a comma separated list of names and wildcard patterns,
+// in sorted order. An empty expression, `*` and `_all` match all the names.
+func matchNames(expr string, names []string) (matched []string, missing []string) {
+	seen := map[string]bool{}
+	for _, e := range strings.Split(expr, ",") {
+		e = strings.TrimSpace(e)
+		if e == "" || e == "_all" {
+			e = "*"
+		}
+		found := false
+		for _, name := range names {
+			if ok, _ := path.Match(e, name); ok {
+				found = true
+				if !seen[name] {
+					seen[name] = true
+					matched = append(matched, name)
+				}
+			}
+		}
+		if !found && !strings.Contains(e, "*") {
+			missing = append(missing, e)
+		}
+	}
+	sort.Strings(matched)
+	return matched, missing
+}
+
+func keys[T any](m map[string]T) []string {
+	var result []string
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// copyMap returns a deep copy of a decoded JSON object, so stored objects can't be altered by responses.
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	data, _ := json.Marshal(m)
+	result := map[string]interface{}{}
+	_ = json.Unmarshal(data, &result)
+	return result
+}
+
+// mergeMaps deeply merges src into dst.
+func mergeMaps(dst, src map[string]interface{}) {
+	for k, v := range src {
+		if srcMap, ok := v.(map[string]interface{}); ok {
+			if dstMap, ok := dst[k].(map[string]interface{}); ok {
+				mergeMaps(dstMap, srcMap)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
+// compactJSON returns the compact JSON encoding of a decoded value.
+func compactJSON(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func queryBool(r *http.Request, name string, defaultValue bool) bool {
+	v := r.URL.Query().Get(name)
+	switch v {
+	case "":
+		if _, ok := r.URL.Query()[name]; ok {
+			return true
+		}
+		return defaultValue
+	case "false":
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,483 @@
+package fakeserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest/fakeserver"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func newClient(t *testing.T, opts ...fakeserver.Option) *clients.ApiClient {
+	t.Helper()
+	server := fakeserver.New(opts...)
+	t.Cleanup(server.Close)
+
+	t.Setenv("ELASTICSEARCH_ENDPOINTS", server.URL)
+	t.Setenv("KIBANA_ENDPOINT", server.URL)
+	t.Setenv("ELASTICSEARCH_USERNAME", "elastic")
+	t.Setenv("ELASTICSEARCH_PASSWORD", "changeme")
+	t.Setenv("ELASTICSEARCH_API_KEY", "")
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func checkDiags(t *testing.T, diags diag.Diagnostics) {
+	t.Helper()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+}
+
+func TestServerVersion(t *testing.T) {
+	ctx := context.Background()
+
+	client := newClient(t)
+	v, diags := client.ServerVersion(ctx)
+	checkDiags(t, diags)
+	if v.String() != fakeserver.DefaultVersion {
+		t.Errorf("expected version %s, got %s", fakeserver.DefaultVersion, v)
+	}
+
+	client = newClient(t, fakeserver.WithVersion("7.17.1"))
+	v, diags = client.ServerVersion(ctx)
+	checkDiags(t, diags)
+	if v.String() != "7.17.1" {
+		t.Errorf("expected version 7.17.1, got %s", v)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	index := &models.Index{
+		Name:     "test-index",
+		Settings: map[string]interface{}{"index.number_of_shards": 2},
+		Mappings: map[string]interface{}{"properties": map[string]interface{}{"field": map[string]interface{}{"type": "keyword"}}},
+		Aliases:  map[string]models.IndexAlias{"test-alias": {Name: "test-alias"}},
+	}
+	checkDiags(t, elasticsearch.PutIndex(ctx, client, index, &models.PutIndexParams{}))
+	if diags := elasticsearch.PutIndex(ctx, client, index, &models.PutIndexParams{}); !diags.HasError() {
+		t.Error("expected an error when creating an existing index")
+	}
+
+	checkDiags(t, elasticsearch.UpdateIndexSettings(ctx, client, index.Name, map[string]interface{}{"index.number_of_replicas": 0}))
+	checkDiags(t, elasticsearch.UpdateIndexMappings(ctx, client, index.Name, `{"properties":{"other":{"type":"long"}}}`))
+	if diags := elasticsearch.UpdateIndexMappings(ctx, client, index.Name, `{"properties":{"field":{"type":"long"}}}`); !diags.HasError() {
+		t.Error("expected an error when changing the type of a field")
+	}
+	checkDiags(t, elasticsearch.DeleteIndexAlias(ctx, client, index.Name, []string{"test-alias"}))
+
+	got, diags := elasticsearch.GetIndex(ctx, client, index.Name)
+	checkDiags(t, diags)
+	if got.Settings["index.number_of_shards"] != "2" || got.Settings["index.number_of_replicas"] != "0" {
+		t.Errorf("unexpected settings: %v", got.Settings)
+	}
+	if properties := got.Mappings["properties"].(map[string]interface{}); len(properties) != 2 {
+		t.Errorf("unexpected mappings: %v", got.Mappings)
+	}
+	if len(got.Aliases) != 0 {
+		t.Errorf("unexpected aliases: %v", got.Aliases)
+	}
+
+	checkDiags(t, elasticsearch.DeleteIndex(ctx, client, index.Name))
+	got, diags = elasticsearch.GetIndex(ctx, client, index.Name)
+	checkDiags(t, diags)
+	if got != nil {
+		t.Errorf("expected the index to be deleted, got %v", got)
+	}
+}
+
+func TestIndicesExpandWildcards(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	for _, name := range []string{"logs-open", "logs-closed"} {
+		checkDiags(t, elasticsearch.PutIndex(ctx, client, &models.Index{Name: name}, &models.PutIndexParams{}))
+	}
+	hidden := &models.Index{Name: "logs-hidden", Settings: map[string]interface{}{"index.hidden": true}}
+	checkDiags(t, elasticsearch.PutIndex(ctx, client, hidden, &models.PutIndexParams{}))
+	esClient, err := client.GetESClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := esClient.Indices.Close([]string{"logs-closed"})
+	if err != nil || res.IsError() {
+		t.Fatalf("failed to close the index: %v %v", err, res)
+	}
+	res.Body.Close()
+
+	for expandWildcards, expected := range map[string][]string{
+		"open":        {"logs-open"},
+		"closed":      {"logs-closed"},
+		"open,hidden": {"logs-hidden", "logs-open"},
+		"all":         {"logs-closed", "logs-hidden", "logs-open"},
+	} {
+		indices, diags := elasticsearch.GetIndices(ctx, client, "logs-*", expandWildcards)
+		checkDiags(t, diags)
+		stats, diags := elasticsearch.GetIndicesStats(ctx, client, "logs-*", expandWildcards)
+		checkDiags(t, diags)
+		if len(indices) != len(expected) || len(stats) != len(expected) {
+			t.Errorf("expected %v with %s, got %v and %v", expected, expandWildcards, indices, stats)
+			continue
+		}
+		for _, name := range expected {
+			if indices[name].Name != name || stats[name].Index != name {
+				t.Errorf("expected %s to match with %s, got %v and %v", name, expandWildcards, indices, stats)
+			}
+		}
+	}
+	if stats, _ := elasticsearch.GetIndicesStats(ctx, client, "logs-closed", ""); stats["logs-closed"].Status != "close" || stats["logs-closed"].DocsCount != "" {
+		t.Errorf("unexpected statistics of a closed index: %v", stats)
+	}
+}
+
+func TestResizeIndex(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	source := &models.Index{Name: "source", Settings: map[string]interface{}{"index.number_of_shards": 2}}
+	checkDiags(t, elasticsearch.PutIndex(ctx, client, source, &models.PutIndexParams{}))
+	if diags := elasticsearch.ResizeIndex(ctx, client, "split", "source", "target", map[string]interface{}{"index.number_of_shards": 4}, ""); !diags.HasError() {
+		t.Error("expected the split of a writable index to fail")
+	}
+
+	checkDiags(t, elasticsearch.UpdateIndexSettings(ctx, client, "source", map[string]interface{}{"index.blocks.write": true}))
+	if diags := elasticsearch.ResizeIndex(ctx, client, "split", "source", "target", map[string]interface{}{"index.number_of_shards": 3}, ""); !diags.HasError() {
+		t.Error("expected the split into a number of shards which isn't a multiple to fail")
+	}
+	checkDiags(t, elasticsearch.ResizeIndex(ctx, client, "split", "source", "target", map[string]interface{}{"index.number_of_shards": 4, "index.blocks.write": nil}, ""))
+
+	target, diags := elasticsearch.GetIndex(ctx, client, "target")
+	checkDiags(t, diags)
+	if target.Settings["index.number_of_shards"] != "4" || target.Settings["index.resize.source.name"] != "source" {
+		t.Errorf("unexpected settings of the target index: %v", target.Settings)
+	}
+	if _, ok := target.Settings["index.blocks.write"]; ok {
+		t.Errorf("expected the write block to be reset on the target index: %v", target.Settings)
+	}
+
+	shards, diags := elasticsearch.GetIndexShards(ctx, client, "target")
+	checkDiags(t, diags)
+	if len(shards) != 4+4 || shards[0].Prirep != "p" || shards[0].Node == "" {
+		t.Errorf("unexpected shards of the target index: %v", shards)
+	}
+}
+
+func TestReindex(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	for _, name := range []string{"source", "dest"} {
+		checkDiags(t, elasticsearch.PutIndex(ctx, client, &models.Index{Name: name}, &models.PutIndexParams{}))
+	}
+	esClient, err := client.GetESClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		res, err := esClient.Index("source", strings.NewReader(`{"field": "value"}`))
+		if err != nil || res.IsError() {
+			t.Fatalf("failed to index a document: %v %v", err, res)
+		}
+		res.Body.Close()
+	}
+
+	if _, diags := elasticsearch.Reindex(ctx, client, "source", "dest", "missing-pipeline"); !diags.HasError() {
+		t.Error("expected an error when reindexing through a missing pipeline")
+	}
+	taskId, diags := elasticsearch.Reindex(ctx, client, "source", "dest", "")
+	checkDiags(t, diags)
+	checkDiags(t, elasticsearch.WaitForReindexTask(ctx, client, taskId, time.Minute))
+
+	res, err := esClient.Count(esClient.Count.WithIndex("dest"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var count struct {
+		Count int `json:"count"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count.Count != 3 {
+		t.Errorf("expected 3 documents to be copied, got %d", count.Count)
+	}
+}
+
+func TestCancelReindexTask(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t, fakeserver.WithTaskDuration(time.Hour))
+
+	for _, name := range []string{"source", "dest"} {
+		checkDiags(t, elasticsearch.PutIndex(ctx, client, &models.Index{Name: name}, &models.PutIndexParams{}))
+	}
+	taskId, diags := elasticsearch.Reindex(ctx, client, "source", "dest", "")
+	checkDiags(t, diags)
+	if diags := elasticsearch.WaitForReindexTask(ctx, client, taskId, 0); !diags.HasError() {
+		t.Error("expected the wait for the running task to time out")
+	}
+
+	checkDiags(t, elasticsearch.CancelTask(ctx, client, taskId))
+	if diags := elasticsearch.WaitForReindexTask(ctx, client, taskId, 0); !diags.HasError() || !strings.Contains(diags[0].Summary, "canceled") {
+		t.Errorf("expected the canceled task to fail, got %v", diags)
+	}
+	// the completed task can't be canceled anymore
+	checkDiags(t, elasticsearch.CancelTask(ctx, client, taskId))
+}
+
+func TestTemplatesAndLifecycles(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	checkDiags(t, elasticsearch.PutIlm(ctx, client, &models.Policy{
+		Name:   "test-policy",
+		Phases: map[string]models.Phase{"hot": {Actions: map[string]models.Action{"rollover": {"max_age": "1d"}}}},
+	}))
+	policy, diags := elasticsearch.GetIlm(ctx, client, "test-policy")
+	checkDiags(t, diags)
+	if policy.Policy.Phases["hot"].MinAge != "0ms" {
+		t.Errorf("expected the default min_age, got %v", policy.Policy.Phases["hot"])
+	}
+
+	checkDiags(t, elasticsearch.PutComponentTemplate(ctx, client, &models.ComponentTemplate{
+		Name:     "test-component",
+		Template: &models.Template{Settings: map[string]interface{}{"index.lifecycle.name": "test-policy"}},
+	}))
+	checkDiags(t, elasticsearch.PutIndexTemplate(ctx, client, &models.IndexTemplate{
+		Name:          "test-template",
+		IndexPatterns: []string{"test-*"},
+		ComposedOf:    []string{"test-component"},
+	}))
+	if diags := elasticsearch.DeleteComponentTemplate(ctx, client, "test-component"); !diags.HasError() {
+		t.Error("expected an error when deleting a component template in use")
+	}
+
+	checkDiags(t, elasticsearch.PutIndex(ctx, client, &models.Index{Name: "test-index"}, &models.PutIndexParams{}))
+	index, diags := elasticsearch.GetIndex(ctx, client, "test-index")
+	checkDiags(t, diags)
+	if index.Settings["index.lifecycle.name"] != "test-policy" {
+		t.Errorf("expected the template settings to be applied, got %v", index.Settings)
+	}
+
+	checkDiags(t, elasticsearch.DeleteIndexTemplate(ctx, client, "test-template"))
+	checkDiags(t, elasticsearch.DeleteComponentTemplate(ctx, client, "test-component"))
+	template, diags := elasticsearch.GetIndexTemplate(ctx, client, "test-template")
+	checkDiags(t, diags)
+	if template != nil {
+		t.Errorf("expected the template to be deleted, got %v", template)
+	}
+}
+
+func TestPipelinesAndScripts(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	name := "test-pipeline"
+	checkDiags(t, elasticsearch.PutIngestPipeline(ctx, client, &models.IngestPipeline{
+		Name:       name,
+		Processors: []map[string]interface{}{{"set": map[string]interface{}{"field": "a", "value": "b"}}},
+	}))
+	pipeline, diags := elasticsearch.GetIngestPipeline(ctx, client, &name)
+	checkDiags(t, diags)
+	if len(pipeline.Processors) != 1 {
+		t.Errorf("unexpected processors: %v", pipeline.Processors)
+	}
+	checkDiags(t, elasticsearch.DeleteIngestPipeline(ctx, client, &name))
+
+	checkDiags(t, elasticsearch.PutScript(ctx, client, &models.Script{ID: "test-script", Language: "painless", Source: "return 1"}))
+	if diags := elasticsearch.PutScript(ctx, client, &models.Script{ID: "test-script", Language: "unknown", Source: "return 1"}); !diags.HasError() {
+		t.Error("expected an error for an unknown script language")
+	}
+	script, diags := elasticsearch.GetScript(ctx, client, "test-script")
+	checkDiags(t, diags)
+	if script.Language != "painless" || script.Source != "return 1" {
+		t.Errorf("unexpected script: %v", script)
+	}
+	checkDiags(t, elasticsearch.DeleteScript(ctx, client, "test-script"))
+	script, diags = elasticsearch.GetScript(ctx, client, "test-script")
+	checkDiags(t, diags)
+	if script != nil {
+		t.Errorf("expected the script to be deleted, got %v", script)
+	}
+}
+
+func TestSnapshotLifecycle(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	slm := &models.SnapshotPolicy{Id: "test-slm", Name: "<snap-{now/d}>", Repository: "test-repo", Schedule: "0 30 1 * * ?"}
+	if diags := elasticsearch.PutSlm(ctx, client, slm); !diags.HasError() {
+		t.Error("expected an error when the repository doesn't exist")
+	}
+	checkDiags(t, elasticsearch.PutSnapshotRepository(ctx, client, &models.SnapshotRepository{
+		Name:     "test-repo",
+		Type:     "fs",
+		Settings: map[string]interface{}{"location": "/tmp", "compress": true},
+	}))
+	repo, diags := elasticsearch.GetSnapshotRepository(ctx, client, "test-repo")
+	checkDiags(t, diags)
+	if repo.Settings["compress"] != "true" {
+		t.Errorf("expected the settings to be returned as strings, got %v", repo.Settings)
+	}
+
+	checkDiags(t, elasticsearch.PutSlm(ctx, client, slm))
+	got, diags := elasticsearch.GetSlm(ctx, client, "test-slm")
+	checkDiags(t, diags)
+	if got.Schedule != slm.Schedule || got.Repository != "test-repo" {
+		t.Errorf("unexpected snapshot policy: %v", got)
+	}
+	checkDiags(t, elasticsearch.DeleteSlm(ctx, client, "test-slm"))
+}
+
+func TestSecurity(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	elastic, diags := elasticsearch.GetUser(ctx, client, "elastic")
+	checkDiags(t, diags)
+	if !elastic.IsSystemUser() {
+		t.Error("expected elastic to be a reserved user")
+	}
+
+	password := "password"
+	checkDiags(t, elasticsearch.PutRole(ctx, client, &models.Role{Name: "test-role", Cluster: []string{"monitor"}}))
+	checkDiags(t, elasticsearch.PutUser(ctx, client, &models.User{Username: "test-user", Roles: []string{"test-role"}, Password: &password, Enabled: true}))
+	checkDiags(t, elasticsearch.DisableUser(ctx, client, "test-user"))
+	user, diags := elasticsearch.GetUser(ctx, client, "test-user")
+	checkDiags(t, diags)
+	if user.Enabled || len(user.Roles) != 1 || user.Password != nil {
+		t.Errorf("unexpected user: %v", user)
+	}
+	checkDiags(t, elasticsearch.DeleteUser(ctx, client, "test-user"))
+	user, diags = elasticsearch.GetUser(ctx, client, "test-user")
+	checkDiags(t, diags)
+	if user != nil {
+		t.Errorf("expected the user to be deleted, got %v", user)
+	}
+
+	key, diags := elasticsearch.PutApiKey(client, &models.ApiKey{Name: "test-key", Expiration: "1d"})
+	checkDiags(t, diags)
+	if key.Id == "" || key.Key == "" || key.EncodedKey == "" || key.Expiration == 0 {
+		t.Errorf("unexpected api key: %v", key)
+	}
+	metadata := map[string]interface{}{"env": "test"}
+	checkDiags(t, elasticsearch.UpdateApiKey(ctx, client, key.Id, models.ApiKeyUpdate{Metadata: &metadata}))
+	got, diags := elasticsearch.GetApiKey(client, key.Id)
+	checkDiags(t, diags)
+	if got.Metadata["env"] != "test" {
+		t.Errorf("expected the api key metadata to be updated, got %v", got.Metadata)
+	}
+	crossClusterKey, diags := elasticsearch.CreateCrossClusterApiKey(ctx, client, &models.CrossClusterApiKey{
+		Name:   "test-cross-cluster-key",
+		Access: &models.CrossClusterApiKeyAccess{Search: []models.CrossClusterSearchAccess{{Names: []string{"logs-*"}}}},
+	})
+	checkDiags(t, diags)
+	access := &models.CrossClusterApiKeyAccess{Replication: []models.CrossClusterReplicationAccess{{Names: []string{"archive-*"}}}}
+	checkDiags(t, elasticsearch.UpdateCrossClusterApiKey(ctx, client, crossClusterKey.Id, &models.CrossClusterApiKey{Access: access}))
+	got, diags = elasticsearch.GetApiKey(client, crossClusterKey.Id)
+	checkDiags(t, diags)
+	if got.Type != "cross_cluster" || got.Access == nil || len(got.Access.Search) != 0 || len(got.Access.Replication) != 1 {
+		t.Errorf("unexpected cross-cluster api key: %v", got)
+	}
+	if diags := elasticsearch.UpdateApiKey(ctx, client, crossClusterKey.Id, models.ApiKeyUpdate{Metadata: &metadata}); !diags.HasError() {
+		t.Error("expected the update of a cross-cluster api key as a rest api key to fail")
+	}
+
+	privilege := &models.ApplicationPrivilege{Application: "myapp", Name: "read", Actions: []string{"data:read/*"}}
+	checkDiags(t, elasticsearch.PutApplicationPrivilege(ctx, client, privilege))
+	gotPrivilege, diags := elasticsearch.GetApplicationPrivilege(ctx, client, "myapp", "read")
+	checkDiags(t, diags)
+	if gotPrivilege == nil || len(gotPrivilege.Actions) != 1 || gotPrivilege.Actions[0] != "data:read/*" {
+		t.Errorf("unexpected application privilege: %v", gotPrivilege)
+	}
+	if diags := elasticsearch.PutApplicationPrivilege(ctx, client, &models.ApplicationPrivilege{Application: "myapp", Name: "write", Actions: []string{"write"}}); !diags.HasError() {
+		t.Error("expected an action without a separator to be rejected")
+	}
+	checkDiags(t, elasticsearch.DeleteApplicationPrivilege(ctx, client, "myapp", "read"))
+	gotPrivilege, diags = elasticsearch.GetApplicationPrivilege(ctx, client, "myapp", "read")
+	checkDiags(t, diags)
+	if gotPrivilege != nil {
+		t.Errorf("expected the application privilege to be deleted, got %v", gotPrivilege)
+	}
+
+	accounts, diags := elasticsearch.GetServiceAccounts(ctx, client, "elastic", "fleet-server")
+	checkDiags(t, diags)
+	if _, ok := accounts["elastic/fleet-server"]; !ok || len(accounts) != 1 {
+		t.Errorf("unexpected service accounts: %v", accounts)
+	}
+	token, diags := elasticsearch.CreateServiceToken(ctx, client, "elastic", "fleet-server", "token1")
+	checkDiags(t, diags)
+	if token.Name != "token1" || token.Value == "" {
+		t.Errorf("unexpected service token: %v", token)
+	}
+	credentials, diags := elasticsearch.GetServiceCredentials(ctx, client, "elastic", "fleet-server")
+	checkDiags(t, diags)
+	if credentials == nil || credentials.Count != 1 || credentials.Tokens["token1"] == nil {
+		t.Errorf("unexpected service credentials: %v", credentials)
+	}
+	checkDiags(t, elasticsearch.DeleteServiceToken(ctx, client, "elastic", "fleet-server", "token1"))
+	credentials, diags = elasticsearch.GetServiceCredentials(ctx, client, "elastic", "fleet-server")
+	checkDiags(t, diags)
+	if credentials == nil || credentials.Count != 0 {
+		t.Errorf("expected the service token to be deleted, got %v", credentials)
+	}
+
+	checkDiags(t, elasticsearch.DeleteApiKey(client, key.Id))
+	got, diags = elasticsearch.GetApiKey(client, key.Id)
+	checkDiags(t, diags)
+	if !got.Invalidated {
+		t.Error("expected the api key to be invalidated")
+	}
+}
+
+func TestTransformsAndWatches(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	name := "test-transform"
+	transform := &models.Transform{
+		Name:        name,
+		Source:      &models.TransformSource{Indices: []string{"source-index"}},
+		Destination: &models.TransformDestination{Index: "dest-index"},
+		Latest:      map[string]interface{}{"unique_key": []string{"id"}, "sort": "@timestamp"},
+	}
+	if diags := elasticsearch.PutTransform(ctx, client, transform, &models.PutTransformParams{}); !diags.HasError() {
+		t.Error("expected an error when the source index doesn't exist")
+	}
+	checkDiags(t, elasticsearch.PutTransform(ctx, client, transform, &models.PutTransformParams{DeferValidation: true, Enabled: true}))
+	stats, diags := elasticsearch.GetTransformStats(ctx, client, &name)
+	checkDiags(t, diags)
+	if !stats.IsStarted() {
+		t.Errorf("expected the transform to be started, got %v", stats.State)
+	}
+	checkDiags(t, elasticsearch.DeleteTransform(ctx, client, &name))
+
+	checkDiags(t, elasticsearch.PutWatch(ctx, client, &models.PutWatch{
+		WatchID: "test-watch",
+		Active:  false,
+		Body:    models.WatchBody{Trigger: map[string]interface{}{"schedule": map[string]interface{}{"cron": "0 0/1 * * * ?"}}},
+	}))
+	watch, diags := elasticsearch.GetWatch(ctx, client, "test-watch")
+	checkDiags(t, diags)
+	if watch.Status.State.Active {
+		t.Error("expected the watch to be inactive")
+	}
+	checkDiags(t, elasticsearch.DeleteWatch(ctx, client, "test-watch"))
+	watch, diags = elasticsearch.GetWatch(ctx, client, "test-watch")
+	checkDiags(t, diags)
+	if watch != nil {
+		t.Errorf("expected the watch to be deleted, got %v", watch)
+	}
+}
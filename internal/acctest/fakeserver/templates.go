@@ -0,0 +1,298 @@
+package fakeserver
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+)
+
+func (s *Server) registerTemplateRoutes() {
+	// must be registered before the index template names routes
+	s.handle("POST", "/_index_template/_simulate_index/{index}", s.simulateIndexTemplate)
+	s.handle("POST", "/_index_template/_simulate", s.simulateTemplate)
+	s.handle("POST", "/_index_template/_simulate/{name}", s.simulateTemplate)
+
+	s.handle("GET", "/_index_template", s.getIndexTemplates)
+	s.handle("GET", "/_index_template/{name}", s.getIndexTemplates)
+	s.handle("PUT,POST", "/_index_template/{name}", s.putIndexTemplate)
+	s.handle("DELETE", "/_index_template/{name}", s.deleteIndexTemplate)
+
+	s.handle("GET", "/_component_template", s.getComponentTemplates)
+	s.handle("GET", "/_component_template/{name}", s.getComponentTemplates)
+	s.handle("PUT,POST", "/_component_template/{name}", s.putComponentTemplate)
+	s.handle("DELETE", "/_component_template/{name}", s.deleteComponentTemplate)
+}
+
+func (s *Server) getIndexTemplates(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	matched, missing := matchNames(params["name"], keys(s.indexTemplates))
+	if len(missing) > 0 {
+		writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("index template matching [%s] not found", missing[0]))
+		return
+	}
+	templates := []interface{}{}
+	for _, name := range matched {
+		templates = append(templates, map[string]interface{}{
+			"name":           name,
+			"index_template": templateResponse(s.indexTemplates[name]),
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"index_templates": templates})
+}
+
+func (s *Server) putIndexTemplate(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	if len(stringOrList(body["index_patterns"])) == 0 {
+		writeError(w, http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: index patterns are missing;")
+		return
+	}
+	for _, component := range stringOrList(body["composed_of"]) {
+		if _, ok := s.componentTemplates[component]; !ok {
+			writeError(w, http.StatusBadRequest, "invalid_index_template_exception", fmt.Sprintf("index_template [%s] invalid, cause [index template [%s] specifies component templates [%s] that do not exist]", params["name"], params["name"], component))
+			return
+		}
+	}
+	if queryBool(r, "create", false) {
+		if _, ok := s.indexTemplates[params["name"]]; ok {
+			writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("index template [%s] already exists", params["name"]))
+			return
+		}
+	}
+	s.indexTemplates[params["name"]] = body
+	writeAcknowledged(w)
+}
+
+func (s *Server) deleteIndexTemplate(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	matched, missing := matchNames(params["name"], keys(s.indexTemplates))
+	if len(missing) > 0 {
+		writeError(w, http.StatusNotFound, "index_template_missing_exception", fmt.Sprintf("index_template [%s] missing", missing[0]))
+		return
+	}
+	for _, name := range matched {
+		for ds, dataStream := range s.dataStreams {
+			if dataStream["template"] == name {
+				writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("unable to remove composable templates [%s] as they are in use by a data streams [%s]", name, ds))
+				return
+			}
+		}
+	}
+	for _, name := range matched {
+		delete(s.indexTemplates, name)
+	}
+	writeAcknowledged(w)
+}
+
+func (s *Server) getComponentTemplates(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	matched, missing := matchNames(params["name"], keys(s.componentTemplates))
+	if len(missing) > 0 {
+		writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("component template matching [%s] not found", missing[0]))
+		return
+	}
+	templates := []interface{}{}
+	for _, name := range matched {
+		templates = append(templates, map[string]interface{}{
+			"name":               name,
+			"component_template": templateResponse(s.componentTemplates[name]),
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"component_templates": templates})
+}
+
+func (s *Server) putComponentTemplate(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	if _, ok := body["template"].(map[string]interface{}); !ok {
+		writeError(w, http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: template is missing;")
+		return
+	}
+	s.componentTemplates[params["name"]] = body
+	writeAcknowledged(w)
+}
+
+func (s *Server) deleteComponentTemplate(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	matched, missing := matchNames(params["name"], keys(s.componentTemplates))
+	if len(missing) > 0 {
+		writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("component template matching [%s] not found", missing[0]))
+		return
+	}
+	for _, name := range matched {
+		for template, body := range s.indexTemplates {
+			for _, component := range stringOrList(body["composed_of"]) {
+				if component == name {
+					writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("component templates [%s] cannot be removed as they are still in use by index templates [%s]", name, template))
+					return
+				}
+			}
+		}
+	}
+	for _, name := range matched {
+		delete(s.componentTemplates, name)
+	}
+	writeAcknowledged(w)
+}
+
+// templateResponse returns a stored template with its settings nested under `index`, as Elasticsearch does.
+func templateResponse(body map[string]interface{}) map[string]interface{} {
+	result := copyMap(body)
+	if template, ok := result["template"].(map[string]interface{}); ok {
+		if settings, ok := template["settings"].(map[string]interface{}); ok {
+			template["settings"] = unflattenSettings(normalizeSettings(settings))
+		}
+	}
+	if ds, ok := result["data_stream"].(map[string]interface{}); ok {
+		if _, ok := ds["hidden"]; !ok {
+			ds["hidden"] = false
+		}
+		if _, ok := ds["allow_custom_routing"]; !ok {
+			ds["allow_custom_routing"] = false
+		}
+	}
+	return result
+}
+
+// matchingIndexTemplate returns the index template with the highest priority matching the index name.
+func (s *Server) matchingIndexTemplate(name string) (string, map[string]interface{}) {
+	var matchedName string
+	var matched map[string]interface{}
+	matchedPriority := -1.0
+	for _, templateName := range keys(s.indexTemplates) {
+		template := s.indexTemplates[templateName]
+		priority, _ := template["priority"].(float64)
+		for _, pattern := range stringOrList(template["index_patterns"]) {
+			if ok, _ := path.Match(pattern, name); ok && priority > matchedPriority {
+				matchedName, matched, matchedPriority = templateName, template, priority
+			}
+		}
+	}
+	return matchedName, matched
+}
+
+// composeTemplate merges the component templates and the template of an index template, in order.
+func (s *Server) composeTemplate(name string, template map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, component := range stringOrList(template["composed_of"]) {
+		if body, ok := s.componentTemplates[component]; ok {
+			if t, ok := body["template"].(map[string]interface{}); ok {
+				mergeMaps(result, copyMap(t))
+			}
+		}
+	}
+	if t, ok := template["template"].(map[string]interface{}); ok {
+		mergeMaps(result, copyMap(t))
+	}
+	return result
+}
+
+// simulatedTemplateName is the name of the template defined in the body of the simulation requests.
+const simulatedTemplateName = "simulated_template"
+
+// simulateIndexTemplate resolves the template applied to the index, including the template of the body as
+// if it existed.
+func (s *Server) simulateIndexTemplate(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	if len(body) > 0 {
+		s.indexTemplates[simulatedTemplateName] = body
+		defer delete(s.indexTemplates, simulatedTemplateName)
+	}
+
+	name := params["index"]
+	templateName, template := s.matchingIndexTemplate(name)
+	if template == nil {
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+		return
+	}
+	var overlapping []interface{}
+	for _, other := range keys(s.indexTemplates) {
+		if other == templateName {
+			continue
+		}
+		for _, pattern := range stringOrList(s.indexTemplates[other]["index_patterns"]) {
+			if ok, _ := path.Match(pattern, name); ok {
+				overlapping = append(overlapping, map[string]interface{}{"name": other, "index_patterns": stringOrList(s.indexTemplates[other]["index_patterns"])})
+				break
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, s.simulationResponse(templateName, template, overlapping))
+}
+
+// simulateTemplate resolves the existing index template, or the template of the body.
+func (s *Server) simulateTemplate(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	templateName, template := simulatedTemplateName, body
+	if name := params["name"]; name != "" {
+		if template, ok = s.indexTemplates[name]; !ok {
+			writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("unable to simulate template [%s] that does not exist", name))
+			return
+		}
+		templateName = name
+	}
+	for _, component := range stringOrList(template["composed_of"]) {
+		if _, ok := s.componentTemplates[component]; !ok {
+			writeError(w, http.StatusBadRequest, "invalid_index_template_exception", fmt.Sprintf("index_template [%s] invalid, cause [index template [%s] specifies component templates [%s] that do not exist]", templateName, templateName, component))
+			return
+		}
+	}
+
+	var overlapping []interface{}
+	patterns := stringOrList(template["index_patterns"])
+	for _, other := range keys(s.indexTemplates) {
+		if other == templateName {
+			continue
+		}
+		otherPatterns := stringOrList(s.indexTemplates[other]["index_patterns"])
+		if patternsOverlap(patterns, otherPatterns) {
+			overlapping = append(overlapping, map[string]interface{}{"name": other, "index_patterns": otherPatterns})
+		}
+	}
+	writeJSON(w, http.StatusOK, s.simulationResponse(templateName, template, overlapping))
+}
+
+func (s *Server) simulationResponse(templateName string, template map[string]interface{}, overlapping []interface{}) map[string]interface{} {
+	composed := s.composeTemplate(templateName, template)
+	settings, _ := composed["settings"].(map[string]interface{})
+	mappings, _ := composed["mappings"].(map[string]interface{})
+	aliases, _ := composed["aliases"].(map[string]interface{})
+	if mappings == nil {
+		mappings = map[string]interface{}{}
+	}
+	if aliases == nil {
+		aliases = map[string]interface{}{}
+	}
+	if overlapping == nil {
+		overlapping = []interface{}{}
+	}
+	return map[string]interface{}{
+		"template": map[string]interface{}{
+			"settings": unflattenSettings(normalizeSettings(settings)),
+			"mappings": mappings,
+			"aliases":  aliases,
+		},
+		"overlapping": overlapping,
+	}
+}
+
+// patternsOverlap checks whether an index could match patterns of both lists.
+func patternsOverlap(patterns, otherPatterns []string) bool {
+	for _, p := range patterns {
+		for _, o := range otherPatterns {
+			if ok, _ := path.Match(p, o); ok {
+				return true
+			}
+			if ok, _ := path.Match(o, p); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+package fakeserver
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type transform struct {
+	Config  map[string]interface{}
+	Started bool
+}
+
+type watch struct {
+	Body    map[string]interface{}
+	Active  bool
+	Version int
+}
+
+func (s *Server) registerTransformRoutes() {
+	s.handle("GET", "/_transform", s.getTransforms)
+	s.handle("GET", "/_transform/{id}", s.getTransforms)
+	s.handle("GET", "/_transform/{id}/_stats", s.getTransformStats)
+	s.handle("PUT", "/_transform/{id}", s.putTransform)
+	s.handle("POST", "/_transform/{id}/_update", s.updateTransform)
+	s.handle("POST", "/_transform/{id}/_start", s.startStopTransform(true))
+	s.handle("POST", "/_transform/{id}/_stop", s.startStopTransform(false))
+	s.handle("DELETE", "/_transform/{id}", s.deleteTransform)
+}
+
+func (s *Server) registerWatcherRoutes() {
+	s.handle("GET", "/_watcher/watch/{id}", s.getWatch)
+	s.handle("PUT,POST", "/_watcher/watch/{id}", s.putWatch)
+	s.handle("DELETE", "/_watcher/watch/{id}", s.deleteWatch)
+}
+
+func writeTransformNotFound(w http.ResponseWriter, id string) {
+	writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("Transform with id [%s] could not be found", id))
+}
+
+func (s *Server) getTransforms(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	matched, missing := matchNames(params["id"], keys(s.transforms))
+	if len(missing) > 0 {
+		writeTransformNotFound(w, missing[0])
+		return
+	}
+	transforms := []interface{}{}
+	for _, id := range matched {
+		config := copyMap(s.transforms[id].Config)
+		config["id"] = id
+		transforms = append(transforms, config)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"count": len(transforms), "transforms": transforms})
+}
+
+func (s *Server) getTransformStats(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	matched, missing := matchNames(params["id"], keys(s.transforms))
+	if len(missing) > 0 {
+		writeTransformNotFound(w, missing[0])
+		return
+	}
+	stats := []interface{}{}
+	for _, id := range matched {
+		state := "stopped"
+		if s.transforms[id].Started {
+			state = "started"
+		}
+		stats = append(stats, map[string]interface{}{"id": id, "state": state})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"count": len(stats), "transforms": stats})
+}
+
+// checkTransformSource verifies the source indices of a transform exist, unless the validation is deferred.
+func (s *Server) checkTransformSource(w http.ResponseWriter, r *http.Request, config map[string]interface{}) bool {
+	if queryBool(r, "defer_validation", false) {
+		return true
+	}
+	source, _ := config["source"].(map[string]interface{})
+	for _, name := range stringOrList(source["index"]) {
+		if matched, _ := s.resolveIndices(name); len(matched) == 0 {
+			writeError(w, http.StatusBadRequest, "validation_exception", fmt.Sprintf("Validation Failed: 1: no such index [%s];", name))
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) putTransform(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	id := params["id"]
+	if _, ok := s.transforms[id]; ok {
+		writeError(w, http.StatusConflict, "resource_already_exists_exception", fmt.Sprintf("Transform with id [%s] already exists", id))
+		return
+	}
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	if body["pivot"] == nil && body["latest"] == nil {
+		writeError(w, http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: Transform configuration must specify exactly 1 function;")
+		return
+	}
+	if !s.checkTransformSource(w, r, body) {
+		return
+	}
+	delete(body, "id")
+	if _, ok := body["settings"]; !ok {
+		body["settings"] = map[string]interface{}{}
+	}
+	body["version"] = s.version
+	body["create_time"] = nowMillis()
+	s.transforms[id] = &transform{Config: body}
+	writeAcknowledged(w)
+}
+
+func (s *Server) updateTransform(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	t, ok := s.transforms[params["id"]]
+	if !ok {
+		writeTransformNotFound(w, params["id"])
+		return
+	}
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	for _, k := range []string{"pivot", "latest"} {
+		if _, ok := body[k]; ok {
+			writeError(w, http.StatusBadRequest, "x_content_parse_exception", fmt.Sprintf("[data_frame_transform_config_update] unknown field [%s]", k))
+			return
+		}
+	}
+	if !s.checkTransformSource(w, r, body) {
+		return
+	}
+	for k, v := range body {
+		t.Config[k] = v
+	}
+	response := copyMap(t.Config)
+	response["id"] = params["id"]
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) startStopTransform(start bool) handler {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		t, ok := s.transforms[params["id"]]
+		if !ok {
+			writeTransformNotFound(w, params["id"])
+			return
+		}
+		if start && t.Started {
+			writeError(w, http.StatusConflict, "status_exception", fmt.Sprintf("Cannot start transform [%s] as it is already started.", params["id"]))
+			return
+		}
+		t.Started = start
+		writeAcknowledged(w)
+	}
+}
+
+func (s *Server) deleteTransform(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	t, ok := s.transforms[params["id"]]
+	if !ok {
+		writeTransformNotFound(w, params["id"])
+		return
+	}
+	if t.Started && !queryBool(r, "force", false) {
+		writeError(w, http.StatusConflict, "status_exception", fmt.Sprintf("Cannot delete transform [%s] as the task is running. Stop the task first", params["id"]))
+		return
+	}
+	delete(s.transforms, params["id"])
+	writeAcknowledged(w)
+}
+
+func (s *Server) getWatch(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	wt, ok := s.watches[params["id"]]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"found": false, "_id": params["id"]})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"found":    true,
+		"_id":      params["id"],
+		"_version": wt.Version,
+		"status": map[string]interface{}{
+			"version": wt.Version,
+			"state":   map[string]interface{}{"active": wt.Active},
+		},
+		"watch": copyMap(wt.Body),
+	})
+}
+
+func (s *Server) putWatch(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, ok := decodeBodyOrFail(w, r)
+	if !ok {
+		return
+	}
+	if _, ok := body["trigger"]; !ok {
+		writeError(w, http.StatusBadRequest, "parse_exception", fmt.Sprintf("could not parse watch [%s]. missing required field [trigger]", params["id"]))
+		return
+	}
+	defaults := map[string]interface{}{
+		"input":     map[string]interface{}{"none": map[string]interface{}{}},
+		"condition": map[string]interface{}{"always": map[string]interface{}{}},
+		"actions":   map[string]interface{}{},
+	}
+	for k, v := range defaults {
+		if _, ok := body[k]; !ok {
+			body[k] = v
+		}
+	}
+
+	previous, exists := s.watches[params["id"]]
+	wt := &watch{Body: body, Active: queryBool(r, "active", true), Version: 1}
+	if exists {
+		wt.Version = previous.Version + 1
+	}
+	s.watches[params["id"]] = wt
+	writeJSON(w, http.StatusOK, map[string]interface{}{"_id": params["id"], "_version": wt.Version, "created": !exists})
+}
+
+func (s *Server) deleteWatch(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	wt, ok := s.watches[params["id"]]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"_id": params["id"], "found": false})
+		return
+	}
+	delete(s.watches, params["id"])
+	writeJSON(w, http.StatusOK, map[string]interface{}{"_id": params["id"], "_version": wt.Version + 1, "found": true})
+}
@@ -3,11 +3,14 @@ package clients
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/disaster37/go-kibana-rest/v8"
@@ -26,9 +29,13 @@ type CompositeId struct {
 	ResourceId string
 }
 
+// clusterUUIDRegex matches the cluster UUIDs, which are URL safe base64 encoded.
+var clusterUUIDRegex = regexp.MustCompile(`^[A-Za-z0-9_-]{22}$`)
+
 func CompositeIdFromStr(id string) (*CompositeId, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	idParts := strings.Split(id, "/")
+	// the cluster UUID never contains a slash, unlike the names of some objects
+	idParts := strings.SplitN(id, "/", 2)
 	if len(idParts) != 2 {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -56,6 +63,30 @@ func (c *CompositeId) String() string {
 	return fmt.Sprintf("%s/%s", c.ClusterId, c.ResourceId)
 }
 
+// ImportStateContext imports the resources identified by a CompositeId. The imported ID is either
+// `<cluster_uuid>/<resource identifier>`, or the resource identifier alone.
+func ImportStateContext(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client, diags := NewApiClient(d, meta)
+	if diags.HasError() {
+		return nil, diagsAsError(diags)
+	}
+	compId, diags := client.ImportCompositeId(ctx, d.Id())
+	if diags.HasError() {
+		return nil, diagsAsError(diags)
+	}
+	d.SetId(compId.String())
+	return []*schema.ResourceData{d}, nil
+}
+
+func diagsAsError(diags diag.Diagnostics) error {
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			return fmt.Errorf("%s %s", d.Summary, d.Detail)
+		}
+	}
+	return nil
+}
+
 type ApiClient struct {
 	elasticsearch            *elasticsearch.Client
 	elasticsearchClusterInfo *models.ClusterInfo
@@ -65,7 +96,14 @@ type ApiClient struct {
 
 func NewApiClientFunc(version string) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		return newApiClient(d, version)
+		var esConn, kibanaConn []interface{}
+		if conn, ok := d.GetOk(esKey); ok {
+			esConn = conn.([]interface{})
+		}
+		if conn, ok := d.GetOk(kibanaKey); ok {
+			kibanaConn = conn.([]interface{})
+		}
+		return newApiClient(esConn, kibanaConn, version)
 	}
 }
 
@@ -124,32 +162,76 @@ func NewAcceptanceTestingClient() (*ApiClient, error) {
 
 const esConnectionKey string = "elasticsearch_connection"
 
+const kibanaConnectionKey string = "kibana_connection"
+
 func NewApiClient(d *schema.ResourceData, meta interface{}) (*ApiClient, diag.Diagnostics) {
+	return newApiClientFromResource(d, meta)
+}
+
+// NewApiClientFromDiff returns the client of a resource from its planned changes, to be used in CustomizeDiff functions.
+func NewApiClientFromDiff(d *schema.ResourceDiff, meta interface{}) (*ApiClient, diag.Diagnostics) {
+	return newApiClientFromResource(d, meta)
+}
+
+func newApiClientFromResource(d interface {
+	GetOk(string) (interface{}, bool)
+}, meta interface{}) (*ApiClient, diag.Diagnostics) {
 	defaultClient := meta.(*ApiClient)
 
-	if _, ok := d.GetOk(esConnectionKey); !ok {
-		return defaultClient, nil
+	var esConn, kibanaConn []interface{}
+	if conn, ok := d.GetOk(esConnectionKey); ok {
+		esConn = conn.([]interface{})
 	}
+	if conn, ok := d.GetOk(kibanaConnectionKey); ok {
+		kibanaConn = conn.([]interface{})
+	}
+
+	return defaultClient.withConnections(esConn, kibanaConn)
+}
 
-	version := defaultClient.version
-	baseConfig := buildBaseConfig(d, version, esConnectionKey)
+// withConnections returns a copy of the client using the resource level connections, when defined.
+func (a *ApiClient) withConnections(esConn, kibanaConn []interface{}) (*ApiClient, diag.Diagnostics) {
+	var diags diag.Diagnostics
 
-	esClient, diags := buildEsClient(d, baseConfig, false, esConnectionKey)
-	if diags.HasError() {
-		return nil, diags
+	esConnOk := len(esConn) > 0
+	kibanaConnOk := len(kibanaConn) > 0
+	if !esConnOk && !kibanaConnOk {
+		return a, nil
 	}
 
-	return &ApiClient{
-		elasticsearch:            esClient,
-		elasticsearchClusterInfo: defaultClient.elasticsearchClusterInfo,
-		kibana:                   defaultClient.kibana,
+	version := a.version
+	client := &ApiClient{
+		elasticsearch:            a.elasticsearch,
+		elasticsearchClusterInfo: a.elasticsearchClusterInfo,
+		kibana:                   a.kibana,
 		version:                  version,
-	}, diags
+	}
+
+	if esConnOk {
+		baseConfig := buildBaseConfig(esConn, version)
+		esClient, diags := buildEsClient(esConn, baseConfig, false)
+		if diags.HasError() {
+			return nil, diags
+		}
+		client.elasticsearch = esClient
+	}
+
+	if kibanaConnOk {
+		// the resource level Kibana connection doesn't inherit any credentials
+		baseConfig := BaseConfig{Header: buildHeader(version)}
+		kibanaClient, diags := buildKibanaClient(kibanaConn, baseConfig, false)
+		if diags.HasError() {
+			return nil, diags
+		}
+		client.kibana = kibanaClient
+	}
+
+	return client, diags
 }
 
 func ensureTLSClientConfig(config *elasticsearch.Config) *tls.Config {
 	if config.Transport == nil {
-		config.Transport = http.DefaultTransport.(*http.Transport)
+		config.Transport = http.DefaultTransport.(*http.Transport).Clone()
 	}
 	if config.Transport.(*http.Transport).TLSClientConfig == nil {
 		config.Transport.(*http.Transport).TLSClientConfig = &tls.Config{}
@@ -157,6 +239,23 @@ func ensureTLSClientConfig(config *elasticsearch.Config) *tls.Config {
 	return config.Transport.(*http.Transport).TLSClientConfig
 }
 
+// appendCACert trusts the CA certificate in addition to the system ones. It's set on the transport rather than as
+// the CACert of the config, which the client only accepts for an *http.Transport and not once wrapped for retries.
+func appendCACert(config *elasticsearch.Config, caCert []byte) error {
+	tlsClientConfig := ensureTLSClientConfig(config)
+	if tlsClientConfig.RootCAs == nil {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			return fmt.Errorf("unable to create cert pool: %w", err)
+		}
+		tlsClientConfig.RootCAs = pool
+	}
+	if !tlsClientConfig.RootCAs.AppendCertsFromPEM(caCert) {
+		return errors.New("unable to add CA certificate")
+	}
+	return nil
+}
+
 func (a *ApiClient) GetESClient() (*elasticsearch.Client, error) {
 	if a.elasticsearch == nil {
 		return nil, errors.New("elasticsearch client not found")
@@ -226,6 +325,30 @@ func (a *ApiClient) ServerVersion(ctx context.Context) (*version.Version, diag.D
 	return serverVersion, nil
 }
 
+// ImportCompositeId returns the ID of an imported resource. When the imported ID only holds the resource identifier,
+// the UUID of the connected cluster is added to it. Otherwise the UUID must match the one of the connected cluster.
+func (a *ApiClient) ImportCompositeId(ctx context.Context, importId string) (*CompositeId, diag.Diagnostics) {
+	clusterId, diags := a.ClusterID(ctx)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	idParts := strings.SplitN(importId, "/", 2)
+	if len(idParts) != 2 || !clusterUUIDRegex.MatchString(idParts[0]) {
+		return &CompositeId{ClusterId: *clusterId, ResourceId: importId}, diags
+	}
+	if idParts[0] != *clusterId {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Cluster UUID mismatch",
+			Detail: fmt.Sprintf(`The cluster UUID "%s" of the imported ID doesn't match the UUID "%s" of the connected cluster.
+Import the resource using its identifier alone, or connect to the cluster it belongs to.`, idParts[0], *clusterId),
+		})
+		return nil, diags
+	}
+	return &CompositeId{ClusterId: idParts[0], ResourceId: idParts[1]}, diags
+}
+
 func (a *ApiClient) ClusterID(ctx context.Context) (*string, diag.Diagnostics) {
 	info, diags := a.serverInfo(ctx)
 	if diags.HasError() {
@@ -247,18 +370,21 @@ func (a *ApiClient) ClusterID(ctx context.Context) (*string, diag.Diagnostics) {
 }
 
 type BaseConfig struct {
-	Username string
-	Password string
-	Header   http.Header
+	Username    string
+	Password    string
+	APIKey      string
+	BearerToken string
+	CloudID     string
+	Header      http.Header
 }
 
 // Build base config from ES which can be shared for other resources
-func buildBaseConfig(d *schema.ResourceData, version string, esKey string) BaseConfig {
+func buildBaseConfig(esConn []interface{}, version string) BaseConfig {
 	baseConfig := BaseConfig{}
 	baseConfig.Header = buildHeader(version)
 
-	if esConn, ok := d.GetOk(esKey); ok {
-		if resource := esConn.([]interface{})[0]; resource != nil {
+	if len(esConn) > 0 {
+		if resource := esConn[0]; resource != nil {
 			config := resource.(map[string]interface{})
 
 			if username, ok := config["username"]; ok {
@@ -267,6 +393,15 @@ func buildBaseConfig(d *schema.ResourceData, version string, esKey string) BaseC
 			if password, ok := config["password"]; ok {
 				baseConfig.Password = password.(string)
 			}
+			if apiKey, ok := config["api_key"]; ok {
+				baseConfig.APIKey = apiKey.(string)
+			}
+			if bearerToken, ok := config["bearer_token"]; ok {
+				baseConfig.BearerToken = bearerToken.(string)
+			}
+			if cloudId, ok := config["cloud_id"]; ok {
+				baseConfig.CloudID = cloudId.(string)
+			}
 		}
 	}
 
@@ -277,11 +412,10 @@ func buildHeader(version string) http.Header {
 	return http.Header{"User-Agent": []string{fmt.Sprintf("elasticstack-terraform-provider/%s", version)}}
 }
 
-func buildEsClient(d *schema.ResourceData, baseConfig BaseConfig, useEnvAsDefault bool, key string) (*elasticsearch.Client, diag.Diagnostics) {
+func buildEsClient(esConn []interface{}, baseConfig BaseConfig, useEnvAsDefault bool) (*elasticsearch.Client, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	esConn, ok := d.GetOk(key)
-	if !ok {
+	if len(esConn) == 0 {
 		return nil, diags
 	}
 
@@ -290,16 +424,26 @@ func buildEsClient(d *schema.ResourceData, baseConfig BaseConfig, useEnvAsDefaul
 		Username: baseConfig.Username,
 		Password: baseConfig.Password,
 	}
+	logger := &debugLogger{Name: "elasticsearch"}
 
 	// if defined, then we only have a single entry
-	if es := esConn.([]interface{})[0]; es != nil {
+	if es := esConn[0]; es != nil {
 		esConfig := es.(map[string]interface{})
 
 		if apikey, ok := esConfig["api_key"]; ok {
 			config.APIKey = apikey.(string)
 		}
 
-		if useEnvAsDefault {
+		if bearerToken, ok := esConfig["bearer_token"]; ok {
+			config.ServiceToken = bearerToken.(string)
+		}
+
+		if cloudId, ok := esConfig["cloud_id"]; ok && cloudId.(string) != "" {
+			config.CloudID = cloudId.(string)
+		}
+
+		// the client doesn't allow to set both endpoints and cloud ID
+		if useEnvAsDefault && config.CloudID == "" {
 			if endpoints := os.Getenv("ELASTICSEARCH_ENDPOINTS"); endpoints != "" {
 				var addrs []string
 				for _, e := range strings.Split(endpoints, ",") {
@@ -322,8 +466,9 @@ func buildEsClient(d *schema.ResourceData, baseConfig BaseConfig, useEnvAsDefaul
 			tlsClientConfig.InsecureSkipVerify = true
 		}
 
+		var caCert []byte
 		if caFile, ok := esConfig["ca_file"]; ok && caFile.(string) != "" {
-			caCert, err := os.ReadFile(caFile.(string))
+			cert, err := os.ReadFile(caFile.(string))
 			if err != nil {
 				diags = append(diags, diag.Diagnostic{
 					Severity: diag.Error,
@@ -332,10 +477,20 @@ func buildEsClient(d *schema.ResourceData, baseConfig BaseConfig, useEnvAsDefaul
 				})
 				return nil, diags
 			}
-			config.CACert = caCert
+			caCert = cert
 		}
 		if caData, ok := esConfig["ca_data"]; ok && caData.(string) != "" {
-			config.CACert = []byte(caData.(string))
+			caCert = []byte(caData.(string))
+		}
+		if caCert != nil {
+			if err := appendCACert(&config, caCert); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to add CA certificate",
+					Detail:   err.Error(),
+				})
+				return nil, diags
+			}
 		}
 
 		if certFile, ok := esConfig["cert_file"]; ok && certFile.(string) != "" {
@@ -382,11 +537,28 @@ func buildEsClient(d *schema.ResourceData, baseConfig BaseConfig, useEnvAsDefaul
 				return nil, diags
 			}
 		}
+
+		if headers, ok := esConfig["redacted_headers"]; ok {
+			logger.RedactedHeaders = utils.ExpandStringSet(headers.(*schema.Set))
+		}
+		if fields, ok := esConfig["redacted_fields"]; ok {
+			logger.RedactedFields = utils.ExpandStringSet(fields.(*schema.Set))
+		}
+
+		if retry, ok := esConfig["retry"]; ok && len(retry.([]interface{})) > 0 {
+			retryConfig, diags := expandRetryConfig(retry.([]interface{}))
+			if diags.HasError() {
+				return nil, diags
+			}
+			// the retry transport replaces the built-in retries of the client
+			config.Transport = newRetryTransport(config.Transport, retryConfig, "elasticsearch")
+			config.DisableRetry = true
+		}
 	}
 
 	if logging.IsDebugOrHigher() {
 		config.EnableDebugLogger = true
-		config.Logger = &debugLogger{Name: "elasticsearch"}
+		config.Logger = logger
 	}
 
 	es, err := elasticsearch.NewClient(config)
@@ -402,11 +574,10 @@ func buildEsClient(d *schema.ResourceData, baseConfig BaseConfig, useEnvAsDefaul
 	return es, diags
 }
 
-func buildKibanaClient(d *schema.ResourceData, baseConfig BaseConfig) (*kibana.Client, diag.Diagnostics) {
+func buildKibanaClient(kibConn []interface{}, baseConfig BaseConfig, useEnvAsDefault bool) (*kibana.Client, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	kibConn, ok := d.GetOk("kibana")
-	if !ok {
+	if len(kibConn) == 0 {
 		return nil, diags
 	}
 
@@ -416,26 +587,51 @@ func buildKibanaClient(d *schema.ResourceData, baseConfig BaseConfig) (*kibana.C
 		Password: baseConfig.Password,
 	}
 
+	apiKey := baseConfig.APIKey
+	bearerToken := baseConfig.BearerToken
+	// credentials configured for Kibana replace all the ones of Elasticsearch
+	useKibanaCredentials := func() {
+		config.Username, config.Password, apiKey, bearerToken = "", "", "", ""
+	}
+	var caCert []byte
+	var certs []tls.Certificate
+	var redactedHeaders, redactedFields []string
+
 	// if defined, then we only have a single entry
-	if kib := kibConn.([]interface{})[0]; kib != nil {
+	if kib := kibConn[0]; kib != nil {
 		kibConfig := kib.(map[string]interface{})
 
-		if username := os.Getenv("KIBANA_USERNAME"); username != "" {
-			config.Username = strings.TrimSpace(username)
+		if headers, ok := kibConfig["redacted_headers"]; ok {
+			redactedHeaders = utils.ExpandStringSet(headers.(*schema.Set))
 		}
-		if password := os.Getenv("KIBANA_PASSWORD"); password != "" {
-			config.Password = strings.TrimSpace(password)
+		if fields, ok := kibConfig["redacted_fields"]; ok {
+			redactedFields = utils.ExpandStringSet(fields.(*schema.Set))
 		}
-		if endpoint := os.Getenv("KIBANA_ENDPOINT"); endpoint != "" {
-			config.Address = endpoint
+
+		if useEnvAsDefault {
+			if username := os.Getenv("KIBANA_USERNAME"); username != "" {
+				useKibanaCredentials()
+				config.Username = strings.TrimSpace(username)
+			}
+			if password := os.Getenv("KIBANA_PASSWORD"); password != "" {
+				config.Password = strings.TrimSpace(password)
+			}
+			if endpoint := os.Getenv("KIBANA_ENDPOINT"); endpoint != "" {
+				config.Address = endpoint
+			}
 		}
 
 		if username, ok := kibConfig["username"]; ok && username != "" {
+			useKibanaCredentials()
 			config.Username = username.(string)
 		}
 		if password, ok := kibConfig["password"]; ok && password != "" {
 			config.Password = password.(string)
 		}
+		if key, ok := kibConfig["api_key"]; ok && key.(string) != "" {
+			useKibanaCredentials()
+			apiKey = key.(string)
+		}
 
 		if endpoints, ok := kibConfig["endpoints"]; ok && len(endpoints.([]interface{})) > 0 {
 			// We're curently limited by the API to a single endpoint
@@ -444,40 +640,151 @@ func buildKibanaClient(d *schema.ResourceData, baseConfig BaseConfig) (*kibana.C
 			}
 		}
 
+		// the Cloud ID is only used when no endpoint is configured
+		if config.Address == "" && baseConfig.CloudID != "" {
+			address, err := kibanaAddrFromCloudID(baseConfig.CloudID)
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to parse Cloud ID",
+					Detail:   err.Error(),
+				})
+				return nil, diags
+			}
+			config.Address = address
+		}
+
 		if insecure, ok := kibConfig["insecure"]; ok && insecure.(bool) {
 			config.DisableVerifySSL = true
 		}
-	}
 
-	kib, err := kibana.NewClient(config)
+		if caFile, ok := kibConfig["ca_file"]; ok && caFile.(string) != "" {
+			cert, err := os.ReadFile(caFile.(string))
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to read CA File",
+					Detail:   err.Error(),
+				})
+				return nil, diags
+			}
+			caCert = cert
+		}
+		if caData, ok := kibConfig["ca_data"]; ok && caData.(string) != "" {
+			caCert = []byte(caData.(string))
+		}
 
-	if logging.IsDebugOrHigher() {
-		kib.Client.SetDebug(true)
+		if certFile, ok := kibConfig["cert_file"]; ok && certFile.(string) != "" {
+			if keyFile, ok := kibConfig["key_file"]; ok && keyFile.(string) != "" {
+				cert, err := tls.LoadX509KeyPair(certFile.(string), keyFile.(string))
+				if err != nil {
+					diags = append(diags, diag.Diagnostic{
+						Severity: diag.Error,
+						Summary:  "Unable to read certificate or key file",
+						Detail:   err.Error(),
+					})
+					return nil, diags
+				}
+				certs = append(certs, cert)
+			} else {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to read key file",
+					Detail:   "Path to key file has not been configured or is empty",
+				})
+				return nil, diags
+			}
+		}
+		if certData, ok := kibConfig["cert_data"]; ok && certData.(string) != "" {
+			if keyData, ok := kibConfig["key_data"]; ok && keyData.(string) != "" {
+				cert, err := tls.X509KeyPair([]byte(certData.(string)), []byte(keyData.(string)))
+				if err != nil {
+					diags = append(diags, diag.Diagnostic{
+						Severity: diag.Error,
+						Summary:  "Unable to parse certificate or key",
+						Detail:   err.Error(),
+					})
+					return nil, diags
+				}
+				certs = append(certs, cert)
+			} else {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to parse key",
+					Detail:   "Key data has not been configured or is empty",
+				})
+				return nil, diags
+			}
+		}
 	}
 
+	kib, err := kibana.NewClient(config)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create Kibana client",
 			Detail:   err.Error(),
 		})
+		return nil, diags
+	}
+
+	if apiKey != "" {
+		// the API key replaces the basic auth credentials set by the Kibana client
+		kib.Client.UserInfo = nil
+		kib.Client.SetAuthScheme("ApiKey")
+		kib.Client.SetAuthToken(apiKey)
+	} else if bearerToken != "" {
+		kib.Client.UserInfo = nil
+		kib.Client.SetAuthScheme("Bearer")
+		kib.Client.SetAuthToken(bearerToken)
+	}
+	if len(caCert) > 0 {
+		kib.Client.SetRootCertificateFromString(string(caCert))
+	}
+	if len(certs) > 0 {
+		kib.Client.SetCertificates(certs...)
+	}
+
+	if logging.IsDebugOrHigher() {
+		kib.Client.SetDebug(true)
+		redactRestyLogs(kib.Client, redactedHeaders, redactedFields)
 	}
 
 	return kib, diags
 }
 
+// kibanaAddrFromCloudID returns the Kibana endpoint encoded in the Cloud ID,
+// which has the form <name>:base64(<host>$<es uuid>$<kibana uuid>).
+func kibanaAddrFromCloudID(cloudId string) (string, error) {
+	values := strings.Split(cloudId, ":")
+	if len(values) != 2 {
+		return "", fmt.Errorf("unexpected format: %q", cloudId)
+	}
+	data, err := base64.StdEncoding.DecodeString(values[1])
+	if err != nil {
+		return "", err
+	}
+	parts := strings.Split(string(data), "$")
+	if len(parts) < 3 || parts[2] == "" {
+		return "", fmt.Errorf("cloud ID %q doesn't contain a Kibana endpoint", values[0])
+	}
+
+	return fmt.Sprintf("https://%s.%s", parts[2], parts[0]), nil
+}
+
 const esKey string = "elasticsearch"
+const kibanaKey string = "kibana"
 
-func newApiClient(d *schema.ResourceData, version string) (*ApiClient, diag.Diagnostics) {
+func newApiClient(esConn, kibanaConn []interface{}, version string) (*ApiClient, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	baseConfig := buildBaseConfig(d, version, esKey)
+	baseConfig := buildBaseConfig(esConn, version)
 
-	esClient, diags := buildEsClient(d, baseConfig, true, esKey)
+	esClient, diags := buildEsClient(esConn, baseConfig, true)
 	if diags.HasError() {
 		return nil, diags
 	}
 
-	kibanaClient, diags := buildKibanaClient(d, baseConfig)
+	kibanaClient, diags := buildKibanaClient(kibanaConn, baseConfig, true)
 	if diags.HasError() {
 		return nil, diags
 	}
@@ -0,0 +1,198 @@
+package clients
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+)
+
+func TestKibanaAddrFromCloudID(t *testing.T) {
+	t.Parallel()
+
+	encode := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		cloudId  string
+		expected string
+		isError  bool
+	}{
+		{"deployment:" + encode("us-central1.gcp.cloud.es.io$es-uuid$kibana-uuid"), "https://kibana-uuid.us-central1.gcp.cloud.es.io", false},
+		{"deployment:" + encode("eastus2.azure.elastic-cloud.com:9243$es-uuid$kibana-uuid"), "https://kibana-uuid.eastus2.azure.elastic-cloud.com:9243", false},
+		{"deployment:" + encode("us-central1.gcp.cloud.es.io$es-uuid"), "", true},
+		{"deployment:not-base64", "", true},
+		{"no-separator", "", true},
+	}
+
+	for _, tc := range tests {
+		address, err := kibanaAddrFromCloudID(tc.cloudId)
+		if tc.isError {
+			if err == nil {
+				t.Errorf("expected an error for %q, got address %q", tc.cloudId, address)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", tc.cloudId, err)
+		}
+		if address != tc.expected {
+			t.Errorf("expected %q, got %q", tc.expected, address)
+		}
+	}
+}
+
+func TestCompositeIdFromStr(t *testing.T) {
+	t.Parallel()
+
+	compId, diags := CompositeIdFromStr("0Nx47cKfQiKSm7Ilg5-LSA/team/pipeline")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if compId.ClusterId != "0Nx47cKfQiKSm7Ilg5-LSA" || compId.ResourceId != "team/pipeline" {
+		t.Errorf("unexpected composite ID: %#v", compId)
+	}
+
+	if _, diags := CompositeIdFromStr("pipeline"); !diags.HasError() {
+		t.Error("expected an error for an ID without cluster UUID")
+	}
+}
+
+func TestImportCompositeId(t *testing.T) {
+	t.Parallel()
+
+	clusterId := "0Nx47cKfQiKSm7Ilg5-LSA"
+	client := &ApiClient{elasticsearchClusterInfo: &models.ClusterInfo{ClusterUUID: clusterId}}
+
+	tests := []struct {
+		importId string
+		expected string
+		isError  bool
+	}{
+		{importId: "pipeline", expected: clusterId + "/pipeline"},
+		{importId: "team/pipeline", expected: clusterId + "/team/pipeline"},
+		{importId: clusterId + "/pipeline", expected: clusterId + "/pipeline"},
+		{importId: clusterId + "/team/pipeline", expected: clusterId + "/team/pipeline"},
+		{importId: "hbYAAVQ2Q5eMdCMkA55hdw/pipeline", isError: true},
+	}
+
+	for _, tc := range tests {
+		compId, diags := client.ImportCompositeId(context.Background(), tc.importId)
+		if tc.isError {
+			if !diags.HasError() {
+				t.Errorf("expected an error for %q, got %q", tc.importId, compId)
+			}
+			continue
+		}
+		if diags.HasError() {
+			t.Errorf("unexpected error for %q: %v", tc.importId, diags)
+			continue
+		}
+		if compId.String() != tc.expected {
+			t.Errorf("expected %q, got %q", tc.expected, compId)
+		}
+	}
+}
+
+func TestBuildKibanaClient(t *testing.T) {
+	t.Parallel()
+
+	cloudId := "deployment:" + base64.StdEncoding.EncodeToString([]byte("us-central1.gcp.cloud.es.io$es-uuid$kibana-uuid"))
+
+	tests := []struct {
+		name            string
+		baseConfig      BaseConfig
+		kibConfig       map[string]interface{}
+		expectedAddress string
+		expectedScheme  string
+		expectedToken   string
+		expectedUser    string
+	}{
+		{
+			name:            "inherits the Elasticsearch API key",
+			baseConfig:      BaseConfig{APIKey: "es-api-key", CloudID: cloudId},
+			kibConfig:       map[string]interface{}{},
+			expectedAddress: "https://kibana-uuid.us-central1.gcp.cloud.es.io",
+			expectedScheme:  "ApiKey",
+			expectedToken:   "es-api-key",
+		},
+		{
+			name:            "inherits the Elasticsearch bearer token",
+			baseConfig:      BaseConfig{BearerToken: "es-token"},
+			kibConfig:       map[string]interface{}{"endpoints": []interface{}{"http://localhost:5601"}},
+			expectedAddress: "http://localhost:5601",
+			expectedScheme:  "Bearer",
+			expectedToken:   "es-token",
+		},
+		{
+			name:            "prefers the Kibana credentials",
+			baseConfig:      BaseConfig{APIKey: "es-api-key"},
+			kibConfig:       map[string]interface{}{"username": "kibana", "password": "changeme"},
+			expectedAddress: "http://localhost:5601",
+			expectedUser:    "kibana",
+		},
+		{
+			name:            "ignores the Cloud ID when an endpoint is configured",
+			baseConfig:      BaseConfig{Username: "elastic", Password: "changeme", CloudID: "not-a-cloud-id"},
+			kibConfig:       map[string]interface{}{"endpoints": []interface{}{"http://localhost:5601"}},
+			expectedAddress: "http://localhost:5601",
+			expectedUser:    "elastic",
+		},
+	}
+
+	for _, tc := range tests {
+		kib, diags := buildKibanaClient([]interface{}{tc.kibConfig}, tc.baseConfig, false)
+		if diags.HasError() {
+			t.Errorf("%s: unexpected error: %v", tc.name, diags)
+			continue
+		}
+		if kib.Client.BaseURL != tc.expectedAddress {
+			t.Errorf("%s: expected address %q, got %q", tc.name, tc.expectedAddress, kib.Client.BaseURL)
+		}
+		if kib.Client.AuthScheme != tc.expectedScheme || kib.Client.Token != tc.expectedToken {
+			t.Errorf("%s: expected %q %q authentication, got %q %q", tc.name, tc.expectedScheme, tc.expectedToken, kib.Client.AuthScheme, kib.Client.Token)
+		}
+		username := ""
+		if kib.Client.UserInfo != nil {
+			username = kib.Client.UserInfo.Username
+		}
+		if username != tc.expectedUser {
+			t.Errorf("%s: expected user %q, got %q", tc.name, tc.expectedUser, username)
+		}
+	}
+
+	if _, diags := buildKibanaClient([]interface{}{map[string]interface{}{}}, BaseConfig{CloudID: "not-a-cloud-id"}, false); !diags.HasError() {
+		t.Error("expected an error for an invalid Cloud ID without Kibana endpoint")
+	}
+}
+
+func TestBuildEsClientWithRetryAndCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		_, _ = w.Write([]byte(`{"cluster_uuid":"uuid","version":{"number":"8.10.0"}}`))
+	}))
+	defer server.Close()
+
+	caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	es, diags := buildEsClient([]interface{}{map[string]interface{}{
+		"endpoints": []interface{}{server.URL},
+		"ca_data":   string(caData),
+		"retry":     []interface{}{map[string]interface{}{"max_attempts": 2}},
+	}}, BaseConfig{}, false)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	res, err := es.Info()
+	if err != nil {
+		t.Fatalf("expected the server certificate to be trusted: %v", err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		t.Errorf("unexpected response: %s", res.String())
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/elastic/go-elasticsearch/v7/estransport"
+	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -24,10 +25,20 @@ const logRespMsg = `%s API Response for [%s] Details:
 %s
 -----------------------------------------------------`
 
+// defaultRedactedHeaders and defaultRedactedFields are always masked in the debug logs.
+// Fields match a JSON key at any depth, or a nested key when given as a dotted path.
+var defaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+var defaultRedactedFields = []string{"password", "password_hash", "api_key", "encoded", "access_token", "refresh_token", "token.value"}
+
+const redactedValue = "********"
+
 var _ estransport.Logger = &debugLogger{}
 
 type debugLogger struct {
 	Name string
+	// RedactedHeaders and RedactedFields extend the default lists of masked headers and JSON fields
+	RedactedHeaders []string
+	RedactedFields  []string
 }
 
 func (l *debugLogger) LogRoundTrip(req *http.Request, resp *http.Response, err error, start time.Time, duration time.Duration) error {
@@ -58,7 +69,7 @@ func (l *debugLogger) logRequest(ctx context.Context, req *http.Request, request
 
 	reqData, err := httputil.DumpRequestOut(req, true)
 	if err == nil {
-		tflog.Debug(ctx, fmt.Sprintf(logReqMsg, l.Name, prettyPrintJsonLines(reqData)))
+		tflog.Debug(ctx, fmt.Sprintf(logReqMsg, l.Name, l.redactedPrettyPrint(reqData)))
 	} else {
 		tflog.Debug(ctx, fmt.Sprintf("%s API request dump error: %#v", l.Name, err))
 	}
@@ -69,7 +80,7 @@ func (l *debugLogger) logResponse(ctx context.Context, resp *http.Response, requ
 
 	respData, err := httputil.DumpResponse(resp, true)
 	if err == nil {
-		tflog.Debug(ctx, fmt.Sprintf(logRespMsg, l.Name, requestId, prettyPrintJsonLines(respData)))
+		tflog.Debug(ctx, fmt.Sprintf(logRespMsg, l.Name, requestId, l.redactedPrettyPrint(respData)))
 	} else {
 		tflog.Debug(ctx, fmt.Sprintf("%s API response for [%s] dump error: %#v", l.Name, requestId, err))
 	}
@@ -78,6 +89,149 @@ func (l *debugLogger) logResponse(ctx context.Context, resp *http.Response, requ
 func (l *debugLogger) RequestBodyEnabled() bool  { return true }
 func (l *debugLogger) ResponseBodyEnabled() bool { return true }
 
+func (l *debugLogger) redactedPrettyPrint(b []byte) string {
+	headers := append(append([]string{}, defaultRedactedHeaders...), l.RedactedHeaders...)
+	fields := append(append([]string{}, defaultRedactedFields...), l.RedactedFields...)
+	return prettyPrintJsonLines(redactDump(b, headers, fields))
+}
+
+// redactDump masks the values of the given headers in the header section of an HTTP dump,
+// and the values of the given fields in any line of the body holding a JSON document.
+func redactDump(b []byte, headers []string, fields []string) []byte {
+	parts := strings.Split(string(b), "\n")
+	inHeaders := true
+	for i, p := range parts {
+		if inHeaders {
+			line := strings.TrimRight(p, "\r")
+			if line == "" {
+				inHeaders = false
+				continue
+			}
+			name, _, found := strings.Cut(line, ":")
+			if found && containsFold(headers, strings.TrimSpace(name)) {
+				parts[i] = fmt.Sprintf("%s: %s", name, redactedValue)
+			}
+			continue
+		}
+
+		if !json.Valid([]byte(p)) {
+			continue
+		}
+		decoder := json.NewDecoder(strings.NewReader(p))
+		decoder.UseNumber()
+		var doc interface{}
+		if err := decoder.Decode(&doc); err != nil {
+			continue
+		}
+		if redacted, changed := redactJson(doc, nil, fields); changed {
+			if out, err := json.Marshal(redacted); err == nil {
+				parts[i] = string(out)
+			}
+		}
+	}
+	return []byte(strings.Join(parts, "\n"))
+}
+
+func redactJson(v interface{}, path []string, fields []string) (interface{}, bool) {
+	changed := false
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, inner := range t {
+			innerPath := append(path[:len(path):len(path)], k)
+			if matchesRedactedField(innerPath, fields) {
+				t[k] = redactedValue
+				changed = true
+				continue
+			}
+			if redacted, innerChanged := redactJson(inner, innerPath, fields); innerChanged {
+				t[k] = redacted
+				changed = true
+			}
+		}
+	case []interface{}:
+		for i, inner := range t {
+			if redacted, innerChanged := redactJson(inner, path, fields); innerChanged {
+				t[i] = redacted
+				changed = true
+			}
+		}
+	}
+	return v, changed
+}
+
+// matchesRedactedField checks whether the path ends with one of the (dotted) fields.
+func matchesRedactedField(path []string, fields []string) bool {
+	for _, f := range fields {
+		segments := strings.Split(f, ".")
+		if len(segments) > len(path) {
+			continue
+		}
+		matches := true
+		for i, s := range segments {
+			if path[len(path)-len(segments)+i] != s {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// redactRestyLogs masks the sensitive headers and fields in the debug logs of the Kibana client,
+// the given ones extending the default lists like for the Elasticsearch client.
+func redactRestyLogs(client *resty.Client, redactedHeaders []string, redactedFields []string) {
+	headers := append(append([]string{}, defaultRedactedHeaders...), redactedHeaders...)
+	fields := append(append([]string{}, defaultRedactedFields...), redactedFields...)
+
+	redactHeaders := func(header http.Header) {
+		for name := range header {
+			if containsFold(headers, name) {
+				header.Set(name, redactedValue)
+			}
+		}
+	}
+	redactBody := func(body string) string {
+		decoder := json.NewDecoder(strings.NewReader(body))
+		decoder.UseNumber()
+		var doc interface{}
+		if err := decoder.Decode(&doc); err != nil {
+			return body
+		}
+		redacted, changed := redactJson(doc, nil, fields)
+		if !changed {
+			return body
+		}
+		out, err := json.MarshalIndent(redacted, "", "   ")
+		if err != nil {
+			return body
+		}
+		return string(out)
+	}
+
+	client.OnRequestLog(func(l *resty.RequestLog) error {
+		redactHeaders(l.Header)
+		l.Body = redactBody(l.Body)
+		return nil
+	})
+	client.OnResponseLog(func(l *resty.ResponseLog) error {
+		redactHeaders(l.Header)
+		l.Body = redactBody(l.Body)
+		return nil
+	})
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
 // prettyPrintJsonLines iterates through a []byte line-by-line,
 // transforming any lines that are complete json into pretty-printed json.
 func prettyPrintJsonLines(b []byte) string {
@@ -90,15 +244,6 @@ func prettyPrintJsonLines(b []byte) string {
 			}
 			parts[i] = out.String()
 		}
-		// Mask Authorization header value
-		if strings.Contains(strings.ToLower(p), "authorization:") {
-			kv := strings.Split(p, ": ")
-			if len(kv) != 2 {
-				continue
-			}
-			kv[1] = strings.Repeat("*", len(kv[1]))
-			parts[i] = strings.Join(kv, ": ")
-		}
 	}
 	return strings.Join(parts, "\n")
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v7/esapi"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
@@ -228,6 +229,63 @@ func GetIndexTemplate(ctx context.Context, apiClient *clients.ApiClient, templat
 	return &tpl, diags
 }
 
+// SimulateIndexTemplate returns the template which would be applied to the index, the template definition being
+// included in the simulation as if it existed when not empty.
+func SimulateIndexTemplate(ctx context.Context, apiClient *clients.ApiClient, indexName, template string) (*models.SimulatedIndexTemplate, diag.Diagnostics) {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	opts := []func(*esapi.IndicesSimulateIndexTemplateRequest){
+		esClient.Indices.SimulateIndexTemplate.WithContext(ctx),
+	}
+	if template != "" {
+		opts = append(opts, esClient.Indices.SimulateIndexTemplate.WithBody(strings.NewReader(template)))
+	}
+	res, err := esClient.Indices.SimulateIndexTemplate(indexName, opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	return decodeSimulatedIndexTemplate(res, fmt.Sprintf("Unable to simulate the index template of index: %s", indexName))
+}
+
+// SimulateTemplate returns the template resolved from the existing index template, or from the template
+// definition when no name is given.
+func SimulateTemplate(ctx context.Context, apiClient *clients.ApiClient, name, template string) (*models.SimulatedIndexTemplate, diag.Diagnostics) {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	opts := []func(*esapi.IndicesSimulateTemplateRequest){
+		esClient.Indices.SimulateTemplate.WithContext(ctx),
+	}
+	if name != "" {
+		opts = append(opts, esClient.Indices.SimulateTemplate.WithName(name))
+	}
+	if template != "" {
+		opts = append(opts, esClient.Indices.SimulateTemplate.WithBody(strings.NewReader(template)))
+	}
+	res, err := esClient.Indices.SimulateTemplate(opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	return decodeSimulatedIndexTemplate(res, "Unable to simulate the index template")
+}
+
+func decodeSimulatedIndexTemplate(res *esapi.Response, errorMessage string) (*models.SimulatedIndexTemplate, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if diags := utils.CheckError(res, errorMessage); diags.HasError() {
+		return nil, diags
+	}
+	var simulated models.SimulatedIndexTemplate
+	if err := json.NewDecoder(res.Body).Decode(&simulated); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &simulated, diags
+}
+
 func DeleteIndexTemplate(ctx context.Context, apiClient *clients.ApiClient, templateName string) diag.Diagnostics {
 	var diags diag.Diagnostics
 	esClient, err := apiClient.GetESClient()
@@ -301,24 +359,41 @@ func DeleteIndex(ctx context.Context, apiClient *clients.ApiClient, name string)
 }
 
 func GetIndex(ctx context.Context, apiClient *clients.ApiClient, name string) (*models.Index, diag.Diagnostics) {
+	indices, diags := GetIndices(ctx, apiClient, name, "")
+	// if there is no index found, return the empty struct, which should force the creation of the index
+	if indices == nil || diags.HasError() {
+		return nil, diags
+	}
+	index := indices[name]
+	return &index, diags
+}
+
+// GetIndices returns the indices matching the comma separated names and wildcard patterns, keyed by name. The
+// wildcards are expanded to the indices of the `expandWildcards` states, or the open indices when empty.
+func GetIndices(ctx context.Context, apiClient *clients.ApiClient, pattern, expandWildcards string) (map[string]models.Index, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	esClient, err := apiClient.GetESClient()
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
-	req := esClient.Indices.Get.WithFlatSettings(true)
-	res, err := esClient.Indices.Get([]string{name}, req, esClient.Indices.Get.WithContext(ctx))
+	opts := []func(*esapi.IndicesGetRequest){
+		esClient.Indices.Get.WithFlatSettings(true),
+		esClient.Indices.Get.WithContext(ctx),
+	}
+	if expandWildcards != "" {
+		opts = append(opts, esClient.Indices.Get.WithExpandWildcards(expandWildcards))
+	}
+	res, err := esClient.Indices.Get([]string{pattern}, opts...)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
 	defer res.Body.Close()
-	// if there is no index found, return the empty struct, which should force the creation of the index
 	if res.StatusCode == http.StatusNotFound {
 		return nil, nil
 	}
 
-	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get requested index: %s", name)); diags.HasError() {
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get requested index: %s", pattern)); diags.HasError() {
 		return nil, diags
 	}
 
@@ -326,8 +401,54 @@ func GetIndex(ctx context.Context, apiClient *clients.ApiClient, name string) (*
 	if err := json.NewDecoder(res.Body).Decode(&indices); err != nil {
 		return nil, diag.FromErr(err)
 	}
-	index := indices[name]
-	return &index, diags
+	for name, index := range indices {
+		index.Name = name
+		indices[name] = index
+	}
+	return indices, diags
+}
+
+// GetIndicesStats returns the health, documents count and store size of the indices matching the comma
+// separated names and wildcard patterns, keyed by name.
+func GetIndicesStats(ctx context.Context, apiClient *clients.ApiClient, pattern, expandWildcards string) (map[string]models.IndexStats, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	opts := []func(*esapi.CatIndicesRequest){
+		esClient.Cat.Indices.WithIndex(pattern),
+		esClient.Cat.Indices.WithFormat("json"),
+		esClient.Cat.Indices.WithBytes("b"),
+		esClient.Cat.Indices.WithH("health", "status", "index", "uuid", "docs.count", "store.size", "creation.date"),
+		esClient.Cat.Indices.WithContext(ctx),
+	}
+	if expandWildcards != "" {
+		opts = append(opts, esClient.Cat.Indices.WithExpandWildcards(expandWildcards))
+	}
+	res, err := esClient.Cat.Indices(opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the statistics of the requested index: %s", pattern)); diags.HasError() {
+		return nil, diags
+	}
+
+	var stats []models.IndexStats
+	if err := json.NewDecoder(res.Body).Decode(&stats); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	result := make(map[string]models.IndexStats, len(stats))
+	for _, s := range stats {
+		result[s.Index] = s
+	}
+	return result, diags
 }
 
 func DeleteIndexAlias(ctx context.Context, apiClient *clients.ApiClient, index string, aliases []string) diag.Diagnostics {
@@ -369,7 +490,366 @@ func UpdateIndexAlias(ctx context.Context, apiClient *clients.ApiClient, index s
 	return diags
 }
 
+// GetAlias returns the definitions of the alias keyed by the names of the indices it points to, nil when
+// the alias doesn't exist.
+func GetAlias(ctx context.Context, apiClient *clients.ApiClient, name string) (map[string]models.IndexAlias, diag.Diagnostics) {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res, err := esClient.Indices.GetAlias(esClient.Indices.GetAlias.WithName(name), esClient.Indices.GetAlias.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get alias '%s'", name)); diags.HasError() {
+		return nil, diags
+	}
+
+	indices := make(map[string]struct {
+		Aliases map[string]models.IndexAlias `json:"aliases"`
+	})
+	if err := json.NewDecoder(res.Body).Decode(&indices); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	aliases := make(map[string]models.IndexAlias, len(indices))
+	for index, v := range indices {
+		if alias, ok := v.Aliases[name]; ok {
+			alias.Name = name
+			aliases[index] = alias
+		}
+	}
+	if len(aliases) == 0 {
+		return nil, nil
+	}
+	return aliases, nil
+}
+
+// UpdateAliases applies the actions in a single call to the aliases API, either all of them are applied or none.
+func UpdateAliases(ctx context.Context, apiClient *clients.ApiClient, actions []models.AliasAction) diag.Diagnostics {
+	body := make([]map[string]interface{}, 0, len(actions))
+	for _, a := range actions {
+		definition := map[string]interface{}{}
+		if a.Type == "add" {
+			aliasBytes, err := json.Marshal(a.Alias)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if err := json.Unmarshal(aliasBytes, &definition); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		definition["index"] = a.Index
+		// the remove_index action deletes the index along with its aliases
+		if a.Type != "remove_index" {
+			definition["alias"] = a.Alias.Name
+		}
+		body = append(body, map[string]interface{}{a.Type: definition})
+	}
+	actionsBytes, err := json.Marshal(map[string]interface{}{"actions": body})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := esClient.Indices.UpdateAliases(bytes.NewReader(actionsBytes), esClient.Indices.UpdateAliases.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to update aliases"); diags.HasError() {
+		return diags
+	}
+	return nil
+}
+
+// Reindex starts copying the documents of the source index to the destination index, optionally through
+// the ingest pipeline, and returns the ID of the reindex task.
+func Reindex(ctx context.Context, apiClient *clients.ApiClient, source, dest, pipeline string) (string, diag.Diagnostics) {
+	destination := map[string]interface{}{"index": dest}
+	if pipeline != "" {
+		destination["pipeline"] = pipeline
+	}
+	reindexBytes, err := json.Marshal(map[string]interface{}{
+		"source": map[string]interface{}{"index": source},
+		"dest":   destination,
+	})
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+	res, err := esClient.Reindex(bytes.NewReader(reindexBytes), esClient.Reindex.WithWaitForCompletion(false), esClient.Reindex.WithContext(ctx))
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to reindex '%s' into '%s'", source, dest)); diags.HasError() {
+		return "", diags
+	}
+
+	var task struct {
+		Task string `json:"task"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&task); err != nil {
+		return "", diag.FromErr(err)
+	}
+	return task.Task, nil
+}
+
+// WaitForReindexTask waits until the reindex task completes, failing when the timeout expires, when the
+// task is canceled or when some of the documents couldn't be copied.
+func WaitForReindexTask(ctx context.Context, apiClient *clients.ApiClient, taskId string, timeout time.Duration) diag.Diagnostics {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		res, err := esClient.Tasks.Get(taskId, esClient.Tasks.Get.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the reindex task '%s'", taskId)); diags.HasError() {
+			res.Body.Close()
+			return diags
+		}
+
+		var task struct {
+			Completed bool                   `json:"completed"`
+			Error     map[string]interface{} `json:"error"`
+			Response  struct {
+				Failures []interface{} `json:"failures"`
+				Canceled string        `json:"canceled"`
+			} `json:"response"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&task)
+		res.Body.Close()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if task.Completed {
+			if task.Error != nil {
+				return diag.Errorf("The reindex task '%s' failed: %v", taskId, task.Error)
+			}
+			if len(task.Response.Failures) > 0 {
+				return diag.Errorf("The reindex task '%s' failed to copy some documents: %v", taskId, task.Response.Failures)
+			}
+			if task.Response.Canceled != "" {
+				return diag.Errorf("The reindex task '%s' was canceled %s", taskId, task.Response.Canceled)
+			}
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return diag.Errorf("Timed out waiting for the reindex task '%s' to complete", taskId)
+		}
+
+		select {
+		case <-ctx.Done():
+			return diag.FromErr(ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// CancelTask cancels the running task, the task which has already completed isn't found and is ignored.
+func CancelTask(ctx context.Context, apiClient *clients.ApiClient, taskId string) diag.Diagnostics {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := esClient.Tasks.Cancel(esClient.Tasks.Cancel.WithTaskID(taskId), esClient.Tasks.Cancel.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to cancel the task '%s'", taskId)); diags.HasError() {
+		return diags
+	}
+	return nil
+}
+
+// WaitForIndexHealth waits for the index to reach the health status, and optionally for its shards to be
+// relocated, failing once the timeout is reached.
+func WaitForIndexHealth(ctx context.Context, apiClient *clients.ApiClient, index, status string, noRelocatingShards bool, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	opts := []func(*esapi.ClusterHealthRequest){
+		esClient.Cluster.Health.WithIndex(index),
+		esClient.Cluster.Health.WithWaitForStatus(status),
+		esClient.Cluster.Health.WithTimeout(timeout),
+		esClient.Cluster.Health.WithContext(ctx),
+	}
+	if noRelocatingShards {
+		opts = append(opts, esClient.Cluster.Health.WithWaitForNoRelocatingShards(true))
+	}
+	res, err := esClient.Cluster.Health(opts...)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	// the health API responds with 408 once the timeout is reached
+	if res.StatusCode != http.StatusRequestTimeout {
+		if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the health of index: %s", index)); diags.HasError() {
+			return diags
+		}
+	}
+
+	var health struct {
+		Status           string `json:"status"`
+		TimedOut         bool   `json:"timed_out"`
+		RelocatingShards int    `json:"relocating_shards"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&health); err != nil {
+		return diag.FromErr(err)
+	}
+	if health.TimedOut {
+		return diag.Errorf(`Index "%s" didn't reach the %s health status within %s, its status is %s with %d relocating shards`, index, status, timeout, health.Status, health.RelocatingShards)
+	}
+	return diags
+}
+
+// GetIndexShards returns the copies of the shards of the index.
+func GetIndexShards(ctx context.Context, apiClient *clients.ApiClient, index string) ([]models.IndexShard, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res, err := esClient.Cat.Shards(
+		esClient.Cat.Shards.WithIndex(index),
+		esClient.Cat.Shards.WithFormat("json"),
+		esClient.Cat.Shards.WithH("index", "shard", "prirep", "state", "node"),
+		esClient.Cat.Shards.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the shards of index: %s", index)); diags.HasError() {
+		return nil, diags
+	}
+
+	var shards []models.IndexShard
+	if err := json.NewDecoder(res.Body).Decode(&shards); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return shards, diags
+}
+
+// ResizeIndex shrinks, splits or clones the source index into the target index, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-shrink-index.html
+func ResizeIndex(ctx context.Context, apiClient *clients.ApiClient, resizeType, source, target string, settings map[string]interface{}, waitForActiveShards string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	body, err := json.Marshal(map[string]interface{}{"settings": settings})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var res *esapi.Response
+	switch resizeType {
+	case "shrink":
+		res, err = esClient.Indices.Shrink(source, target, esClient.Indices.Shrink.WithBody(bytes.NewReader(body)), esClient.Indices.Shrink.WithWaitForActiveShards(waitForActiveShards), esClient.Indices.Shrink.WithContext(ctx))
+	case "split":
+		res, err = esClient.Indices.Split(source, target, esClient.Indices.Split.WithBody(bytes.NewReader(body)), esClient.Indices.Split.WithWaitForActiveShards(waitForActiveShards), esClient.Indices.Split.WithContext(ctx))
+	case "clone":
+		res, err = esClient.Indices.Clone(source, target, esClient.Indices.Clone.WithBody(bytes.NewReader(body)), esClient.Indices.Clone.WithWaitForActiveShards(waitForActiveShards), esClient.Indices.Clone.WithContext(ctx))
+	default:
+		return diag.Errorf(`Unsupported resize type "%s"`, resizeType)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to %s index '%s' into '%s'", resizeType, source, target)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+// CloseIndex closes the index, blocking the reads and writes, so its static settings can be updated.
+func CloseIndex(ctx context.Context, apiClient *clients.ApiClient, index string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := esClient.Indices.Close([]string{index}, esClient.Indices.Close.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to close index: %s", index)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+// OpenIndex opens the closed index and waits for the number of active shards, failing when they aren't started
+// before the timeout.
+func OpenIndex(ctx context.Context, apiClient *clients.ApiClient, index, waitForActiveShards string, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	opts := []func(*esapi.IndicesOpenRequest){
+		esClient.Indices.Open.WithTimeout(timeout),
+		esClient.Indices.Open.WithContext(ctx),
+	}
+	if waitForActiveShards != "" {
+		opts = append(opts, esClient.Indices.Open.WithWaitForActiveShards(waitForActiveShards))
+	}
+	res, err := esClient.Indices.Open([]string{index}, opts...)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to open index: %s", index)); diags.HasError() {
+		return diags
+	}
+
+	var response struct {
+		ShardsAcknowledged bool `json:"shards_acknowledged"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return diag.FromErr(err)
+	}
+	if !response.ShardsAcknowledged {
+		return diag.Errorf(`Index "%s" was opened but its shards weren't started within %s, check the health of the index`, index, timeout)
+	}
+	return diags
+}
+
 func UpdateIndexSettings(ctx context.Context, apiClient *clients.ApiClient, index string, settings map[string]interface{}) diag.Diagnostics {
+	return UpdateIndicesSettings(ctx, apiClient, index, "", settings)
+}
+
+// UpdateIndicesSettings updates the settings of the indices matching the comma separated names and wildcard
+// patterns. The wildcards are expanded to the indices of the `expandWildcards` states, or the open indices when
+// empty. The settings set to nil are reset to their default value.
+func UpdateIndicesSettings(ctx context.Context, apiClient *clients.ApiClient, pattern, expandWildcards string, settings map[string]interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	settingsBytes, err := json.Marshal(settings)
 	if err != nil {
@@ -379,8 +859,14 @@ func UpdateIndexSettings(ctx context.Context, apiClient *clients.ApiClient, inde
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	req := esClient.Indices.PutSettings.WithIndex(index)
-	res, err := esClient.Indices.PutSettings(bytes.NewReader(settingsBytes), req, esClient.Indices.PutSettings.WithContext(ctx))
+	opts := []func(*esapi.IndicesPutSettingsRequest){
+		esClient.Indices.PutSettings.WithIndex(pattern),
+		esClient.Indices.PutSettings.WithContext(ctx),
+	}
+	if expandWildcards != "" {
+		opts = append(opts, esClient.Indices.PutSettings.WithExpandWildcards(expandWildcards))
+	}
+	res, err := esClient.Indices.PutSettings(bytes.NewReader(settingsBytes), opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
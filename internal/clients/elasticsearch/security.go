@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
+	"github.com/elastic/go-elasticsearch/v7/esapi"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
@@ -292,6 +294,79 @@ func DeleteRoleMapping(ctx context.Context, apiClient *clients.ApiClient, roleMa
 	return nil
 }
 
+func PutApplicationPrivilege(ctx context.Context, apiClient *clients.ApiClient, privilege *models.ApplicationPrivilege) diag.Diagnostics {
+	// privileges are put keyed by application and name
+	privilegeBytes, err := json.Marshal(map[string]map[string]models.ApplicationPrivilege{
+		privilege.Application: {privilege.Name: *privilege},
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := esClient.Security.PutPrivileges(bytes.NewReader(privilegeBytes), esClient.Security.PutPrivileges.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to put application privilege"); diags.HasError() {
+		return diags
+	}
+
+	return nil
+}
+
+func GetApplicationPrivilege(ctx context.Context, apiClient *clients.ApiClient, application, name string) (*models.ApplicationPrivilege, diag.Diagnostics) {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res, err := esClient.Security.GetPrivileges(
+		esClient.Security.GetPrivileges.WithApplication(application),
+		esClient.Security.GetPrivileges.WithName(name),
+		esClient.Security.GetPrivileges.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, "Unable to get application privilege."); diags.HasError() {
+		return nil, diags
+	}
+	privileges := make(map[string]map[string]models.ApplicationPrivilege)
+	if err := json.NewDecoder(res.Body).Decode(&privileges); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if privilege, ok := privileges[application][name]; ok {
+		return &privilege, nil
+	}
+
+	return nil, nil
+}
+
+func DeleteApplicationPrivilege(ctx context.Context, apiClient *clients.ApiClient, application, name string) diag.Diagnostics {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := esClient.Security.DeletePrivileges(name, application, esClient.Security.DeletePrivileges.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to delete application privilege"); diags.HasError() {
+		return diags
+	}
+
+	return nil
+}
+
 func PutApiKey(apiClient *clients.ApiClient, apikey *models.ApiKey) (*models.ApiKeyResponse, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	apikeyBytes, err := json.Marshal(apikey)
@@ -321,6 +396,80 @@ func PutApiKey(apiClient *clients.ApiClient, apikey *models.ApiKey) (*models.Api
 	return &apiKey, diags
 }
 
+// UpdateApiKey updates the role descriptors and metadata of an API key, available since Elasticsearch 8.4.
+func UpdateApiKey(ctx context.Context, apiClient *clients.ApiClient, id string, update models.ApiKeyUpdate) diag.Diagnostics {
+	updateBytes, err := json.Marshal(update)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := performApiKeyRequest(ctx, apiClient, http.MethodPut, "/_security/api_key/"+url.PathEscape(id), updateBytes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to update apikey"); diags.HasError() {
+		return diags
+	}
+	return nil
+}
+
+// CreateCrossClusterApiKey creates an API key for API key based remote cluster access, available since Elasticsearch 8.10.
+func CreateCrossClusterApiKey(ctx context.Context, apiClient *clients.ApiClient, apikey *models.CrossClusterApiKey) (*models.ApiKeyResponse, diag.Diagnostics) {
+	apikeyBytes, err := json.Marshal(apikey)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res, err := performApiKeyRequest(ctx, apiClient, http.MethodPost, "/_security/cross_cluster/api_key", apikeyBytes)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to create cross-cluster apikey"); diags.HasError() {
+		return nil, diags
+	}
+
+	var apiKey models.ApiKeyResponse
+	if err := json.NewDecoder(res.Body).Decode(&apiKey); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &apiKey, nil
+}
+
+// UpdateCrossClusterApiKey updates the access and metadata of a cross-cluster API key.
+func UpdateCrossClusterApiKey(ctx context.Context, apiClient *clients.ApiClient, id string, update *models.CrossClusterApiKey) diag.Diagnostics {
+	updateBytes, err := json.Marshal(update)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := performApiKeyRequest(ctx, apiClient, http.MethodPut, "/_security/cross_cluster/api_key/"+url.PathEscape(id), updateBytes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to update cross-cluster apikey"); diags.HasError() {
+		return diags
+	}
+	return nil
+}
+
+// performApiKeyRequest sends a request to the API key APIs which aren't available in the v7 client.
+func performApiKeyRequest(ctx context.Context, apiClient *clients.ApiClient, method, path string, body []byte) (*esapi.Response, error) {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := esClient.Perform(req)
+	if err != nil {
+		return nil, err
+	}
+	return &esapi.Response{StatusCode: res.StatusCode, Body: res.Body, Header: res.Header}, nil
+}
+
 func GetApiKey(apiClient *clients.ApiClient, id string) (*models.ApiKeyResponse, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	esClient, err := apiClient.GetESClient()
@@ -393,3 +542,107 @@ func DeleteApiKey(apiClient *clients.ApiClient, id string) diag.Diagnostics {
 	}
 	return diags
 }
+
+// GetServiceAccounts returns the service accounts, optionally filtered by namespace and service, keyed by
+// the <namespace>/<service> name of the service account.
+func GetServiceAccounts(ctx context.Context, apiClient *clients.ApiClient, namespace, service string) (map[string]models.ServiceAccount, diag.Diagnostics) {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	opts := []func(*esapi.SecurityGetServiceAccountsRequest){esClient.Security.GetServiceAccounts.WithContext(ctx)}
+	if namespace != "" {
+		opts = append(opts, esClient.Security.GetServiceAccounts.WithNamespace(namespace))
+	}
+	if service != "" {
+		opts = append(opts, esClient.Security.GetServiceAccounts.WithService(service))
+	}
+	res, err := esClient.Security.GetServiceAccounts(opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to get service accounts."); diags.HasError() {
+		return nil, diags
+	}
+
+	serviceAccounts := make(map[string]models.ServiceAccount)
+	if err := json.NewDecoder(res.Body).Decode(&serviceAccounts); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return serviceAccounts, nil
+}
+
+// GetServiceCredentials returns the tokens of the service account, nil if the service account doesn't exist.
+func GetServiceCredentials(ctx context.Context, apiClient *clients.ApiClient, namespace, service string) (*models.ServiceCredentials, diag.Diagnostics) {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res, err := esClient.Security.GetServiceCredentials(namespace, service, esClient.Security.GetServiceCredentials.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, "Unable to get service account credentials."); diags.HasError() {
+		return nil, diags
+	}
+
+	var credentials models.ServiceCredentials
+	if err := json.NewDecoder(res.Body).Decode(&credentials); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &credentials, nil
+}
+
+func CreateServiceToken(ctx context.Context, apiClient *clients.ApiClient, namespace, service, name string) (*models.ServiceToken, diag.Diagnostics) {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res, err := esClient.Security.CreateServiceToken(
+		namespace,
+		service,
+		esClient.Security.CreateServiceToken.WithName(name),
+		esClient.Security.CreateServiceToken.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to create service token"); diags.HasError() {
+		return nil, diags
+	}
+
+	var response struct {
+		Token models.ServiceToken `json:"token"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &response.Token, nil
+}
+
+func DeleteServiceToken(ctx context.Context, apiClient *clients.ApiClient, namespace, service, name string) diag.Diagnostics {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// the client binds the arguments as name, service, namespace despite the declared order
+	res, err := esClient.Security.DeleteServiceToken(name, service, namespace, esClient.Security.DeleteServiceToken.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	// the token is already gone
+	if res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if diags := utils.CheckError(res, "Unable to delete service token"); diags.HasError() {
+		return diags
+	}
+	return nil
+}
@@ -13,13 +13,12 @@ import (
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
-	"github.com/hashicorp/go-version"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
-var transformFeatureMinSupportedVersion = version.Must(version.NewVersion("7.2.0"))
-
-var apiOperationTimeoutParamMinSupportedVersion = version.Must(version.NewVersion("7.17.0"))
+// transformResourceName is the key of the transform requirements in the version registry.
+const transformResourceName = "elasticstack_elasticsearch_transform"
 
 func PutTransform(ctx context.Context, apiClient *clients.ApiClient, transform *models.Transform, params *models.PutTransformParams) diag.Diagnostics {
 
@@ -39,16 +38,16 @@ func PutTransform(ctx context.Context, apiClient *clients.ApiClient, transform *
 		return diags
 	}
 
-	if serverVersion.LessThan(transformFeatureMinSupportedVersion) {
+	if !versionutils.IsSupported(transformResourceName, "", serverVersion) {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Transforms not supported",
-			Detail:   fmt.Sprintf(`Transform feature requires a minimum Elasticsearch version of "%s"`, transformFeatureMinSupportedVersion),
+			Detail:   fmt.Sprintf(`Transform feature requires a minimum Elasticsearch version of "%s"`, versionutils.MinVersion(transformResourceName, "")),
 		})
 		return diags
 	}
 
-	withTimeout := serverVersion.GreaterThanOrEqual(apiOperationTimeoutParamMinSupportedVersion)
+	withTimeout := versionutils.IsSupported(transformResourceName, "timeout", serverVersion)
 
 	putOptions := []func(*esapi.TransformPutTransformRequest){
 		esClient.TransformPutTransform.WithContext(ctx),
@@ -199,16 +198,16 @@ func UpdateTransform(ctx context.Context, apiClient *clients.ApiClient, transfor
 		return diags
 	}
 
-	if serverVersion.LessThan(transformFeatureMinSupportedVersion) {
+	if !versionutils.IsSupported(transformResourceName, "", serverVersion) {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Transforms not supported",
-			Detail:   fmt.Sprintf(`Transform feature requires a minimum Elasticsearch version of "%s"`, transformFeatureMinSupportedVersion),
+			Detail:   fmt.Sprintf(`Transform feature requires a minimum Elasticsearch version of "%s"`, versionutils.MinVersion(transformResourceName, "")),
 		})
 		return diags
 	}
 
-	withTimeout := serverVersion.GreaterThanOrEqual(apiOperationTimeoutParamMinSupportedVersion)
+	withTimeout := versionutils.IsSupported(transformResourceName, "timeout", serverVersion)
 
 	updateOptions := []func(*esapi.TransformUpdateTransformRequest){
 		esClient.TransformUpdateTransform.WithContext(ctx),
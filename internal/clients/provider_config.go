@@ -0,0 +1,237 @@
+package clients
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProviderConfiguration is the provider configuration as decoded by the plugin framework.
+// It is converted to the same raw values the SDK provider works with, so that both
+// providers build their clients the same way.
+type ProviderConfiguration struct {
+	Elasticsearch []ElasticsearchConnection `tfsdk:"elasticsearch"`
+	Kibana        []KibanaConnection        `tfsdk:"kibana"`
+}
+
+type ElasticsearchConnection struct {
+	Username        types.String  `tfsdk:"username"`
+	Password        types.String  `tfsdk:"password"`
+	APIKey          types.String  `tfsdk:"api_key"`
+	BearerToken     types.String  `tfsdk:"bearer_token"`
+	Endpoints       types.List    `tfsdk:"endpoints"`
+	CloudID         types.String  `tfsdk:"cloud_id"`
+	Insecure        types.Bool    `tfsdk:"insecure"`
+	CAFile          types.String  `tfsdk:"ca_file"`
+	CAData          types.String  `tfsdk:"ca_data"`
+	CertFile        types.String  `tfsdk:"cert_file"`
+	KeyFile         types.String  `tfsdk:"key_file"`
+	CertData        types.String  `tfsdk:"cert_data"`
+	KeyData         types.String  `tfsdk:"key_data"`
+	RedactedHeaders types.Set     `tfsdk:"redacted_headers"`
+	RedactedFields  types.Set     `tfsdk:"redacted_fields"`
+	Retry           []RetryPolicy `tfsdk:"retry"`
+}
+
+type RetryPolicy struct {
+	MaxAttempts    types.Int64  `tfsdk:"max_attempts"`
+	RetryOnStatus  types.Set    `tfsdk:"retry_on_status"`
+	InitialBackoff types.String `tfsdk:"initial_backoff"`
+	MaxWait        types.String `tfsdk:"max_wait"`
+}
+
+type KibanaConnection struct {
+	Username        types.String `tfsdk:"username"`
+	Password        types.String `tfsdk:"password"`
+	Endpoints       types.List   `tfsdk:"endpoints"`
+	APIKey          types.String `tfsdk:"api_key"`
+	Insecure        types.Bool   `tfsdk:"insecure"`
+	CAFile          types.String `tfsdk:"ca_file"`
+	CAData          types.String `tfsdk:"ca_data"`
+	CertFile        types.String `tfsdk:"cert_file"`
+	KeyFile         types.String `tfsdk:"key_file"`
+	CertData        types.String `tfsdk:"cert_data"`
+	KeyData         types.String `tfsdk:"key_data"`
+	RedactedHeaders types.Set    `tfsdk:"redacted_headers"`
+	RedactedFields  types.Set    `tfsdk:"redacted_fields"`
+}
+
+// NewApiClientFromFramework builds the provider level client from the plugin framework configuration.
+func NewApiClientFromFramework(ctx context.Context, cfg ProviderConfiguration, version string) (*ApiClient, fwdiag.Diagnostics) {
+	var diags fwdiag.Diagnostics
+
+	esConn, d := expandEsConnections(ctx, cfg.Elasticsearch, true)
+	diags.Append(d...)
+	kibanaConn, d := expandKibanaConnections(ctx, cfg.Kibana, true)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	client, sdkDiags := newApiClient(esConn, kibanaConn, version)
+	diags.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
+	return client, diags
+}
+
+// NewApiClientFromFrameworkResource returns the client of a plugin framework resource,
+// using its elasticsearch_connection block when defined.
+func NewApiClientFromFrameworkResource(ctx context.Context, esConnection []ElasticsearchConnection, defaultClient *ApiClient) (*ApiClient, fwdiag.Diagnostics) {
+	var diags fwdiag.Diagnostics
+
+	esConn, d := expandEsConnections(ctx, esConnection, false)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	client, sdkDiags := defaultClient.withConnections(esConn, nil)
+	diags.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
+	return client, diags
+}
+
+func expandEsConnections(ctx context.Context, connections []ElasticsearchConnection, useEnvAsDefault bool) ([]interface{}, fwdiag.Diagnostics) {
+	var diags fwdiag.Diagnostics
+	if len(connections) == 0 {
+		return nil, diags
+	}
+	conn := connections[0]
+
+	withEnvDefault := func(value types.String, key string) types.String {
+		if useEnvAsDefault && value.IsNull() {
+			if v, ok := os.LookupEnv(key); ok {
+				return types.StringValue(v)
+			}
+		}
+		return value
+	}
+
+	config := map[string]interface{}{}
+	setString(config, "username", withEnvDefault(conn.Username, "ELASTICSEARCH_USERNAME"))
+	setString(config, "password", withEnvDefault(conn.Password, "ELASTICSEARCH_PASSWORD"))
+	setString(config, "api_key", withEnvDefault(conn.APIKey, "ELASTICSEARCH_API_KEY"))
+	setString(config, "bearer_token", withEnvDefault(conn.BearerToken, "ELASTICSEARCH_BEARER_TOKEN"))
+	setString(config, "cloud_id", withEnvDefault(conn.CloudID, "ELASTICSEARCH_CLOUD_ID"))
+	setString(config, "ca_file", conn.CAFile)
+	setString(config, "ca_data", conn.CAData)
+	setString(config, "cert_file", conn.CertFile)
+	setString(config, "key_file", conn.KeyFile)
+	setString(config, "cert_data", conn.CertData)
+	setString(config, "key_data", conn.KeyData)
+
+	insecure := conn.Insecure
+	if useEnvAsDefault && insecure.IsNull() {
+		if v, ok := os.LookupEnv("ELASTICSEARCH_INSECURE"); ok {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				diags.AddError("Invalid value of ELASTICSEARCH_INSECURE", err.Error())
+				return nil, diags
+			}
+			insecure = types.BoolValue(b)
+		}
+	}
+	config["insecure"] = insecure.ValueBool()
+
+	if !conn.Endpoints.IsNull() {
+		var endpoints []string
+		diags.Append(conn.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+		config["endpoints"] = toInterfaceSlice(endpoints)
+	}
+	if !conn.RedactedHeaders.IsNull() {
+		var headers []string
+		diags.Append(conn.RedactedHeaders.ElementsAs(ctx, &headers, false)...)
+		config["redacted_headers"] = schema.NewSet(schema.HashString, toInterfaceSlice(headers))
+	}
+	if !conn.RedactedFields.IsNull() {
+		var fields []string
+		diags.Append(conn.RedactedFields.ElementsAs(ctx, &fields, false)...)
+		config["redacted_fields"] = schema.NewSet(schema.HashString, toInterfaceSlice(fields))
+	}
+
+	if len(conn.Retry) > 0 {
+		retry := conn.Retry[0]
+		retryConfig := map[string]interface{}{}
+		if !retry.MaxAttempts.IsNull() {
+			retryConfig["max_attempts"] = int(retry.MaxAttempts.ValueInt64())
+		}
+		if !retry.RetryOnStatus.IsNull() {
+			var statuses []int64
+			diags.Append(retry.RetryOnStatus.ElementsAs(ctx, &statuses, false)...)
+			var codes []interface{}
+			for _, s := range statuses {
+				codes = append(codes, int(s))
+			}
+			retryConfig["retry_on_status"] = schema.NewSet(schema.HashInt, codes)
+		}
+		setString(retryConfig, "initial_backoff", retry.InitialBackoff)
+		setString(retryConfig, "max_wait", retry.MaxWait)
+		config["retry"] = []interface{}{retryConfig}
+	}
+
+	return []interface{}{config}, diags
+}
+
+func expandKibanaConnections(ctx context.Context, connections []KibanaConnection, useEnvAsDefault bool) ([]interface{}, fwdiag.Diagnostics) {
+	var diags fwdiag.Diagnostics
+	if len(connections) == 0 {
+		return nil, diags
+	}
+	conn := connections[0]
+
+	withEnvDefault := func(value types.String, key string) types.String {
+		if useEnvAsDefault && value.IsNull() {
+			if v, ok := os.LookupEnv(key); ok {
+				return types.StringValue(v)
+			}
+		}
+		return value
+	}
+
+	config := map[string]interface{}{}
+	setString(config, "username", conn.Username)
+	setString(config, "password", conn.Password)
+	setString(config, "api_key", withEnvDefault(conn.APIKey, "KIBANA_API_KEY"))
+	setString(config, "ca_file", withEnvDefault(conn.CAFile, "KIBANA_CA_FILE"))
+	setString(config, "ca_data", withEnvDefault(conn.CAData, "KIBANA_CA_DATA"))
+	setString(config, "cert_file", withEnvDefault(conn.CertFile, "KIBANA_CERT_FILE"))
+	setString(config, "key_file", withEnvDefault(conn.KeyFile, "KIBANA_KEY_FILE"))
+	setString(config, "cert_data", withEnvDefault(conn.CertData, "KIBANA_CERT_DATA"))
+	setString(config, "key_data", withEnvDefault(conn.KeyData, "KIBANA_KEY_DATA"))
+	config["insecure"] = conn.Insecure.ValueBool()
+
+	if !conn.Endpoints.IsNull() {
+		var endpoints []string
+		diags.Append(conn.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+		config["endpoints"] = toInterfaceSlice(endpoints)
+	}
+	if !conn.RedactedHeaders.IsNull() {
+		var headers []string
+		diags.Append(conn.RedactedHeaders.ElementsAs(ctx, &headers, false)...)
+		config["redacted_headers"] = schema.NewSet(schema.HashString, toInterfaceSlice(headers))
+	}
+	if !conn.RedactedFields.IsNull() {
+		var fields []string
+		diags.Append(conn.RedactedFields.ElementsAs(ctx, &fields, false)...)
+		config["redacted_fields"] = schema.NewSet(schema.HashString, toInterfaceSlice(fields))
+	}
+
+	return []interface{}{config}, diags
+}
+
+func setString(config map[string]interface{}, key string, value types.String) {
+	if !value.IsNull() && !value.IsUnknown() {
+		config[key] = value.ValueString()
+	}
+}
+
+func toInterfaceSlice(values []string) []interface{} {
+	var result []interface{}
+	for _, v := range values {
+		result = append(result, v)
+	}
+	return result
+}
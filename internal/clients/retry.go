@@ -0,0 +1,247 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = time.Second
+	defaultRetryMaxWait        = 30 * time.Second
+)
+
+var defaultRetryOnStatus = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+type RetryConfig struct {
+	MaxAttempts    int
+	RetryOnStatus  []int
+	InitialBackoff time.Duration
+	MaxWait        time.Duration
+}
+
+func defaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:    defaultRetryMaxAttempts,
+		RetryOnStatus:  defaultRetryOnStatus,
+		InitialBackoff: defaultRetryInitialBackoff,
+		MaxWait:        defaultRetryMaxWait,
+	}
+}
+
+func expandRetryConfig(raw []interface{}) (RetryConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	config := defaultRetryConfig()
+
+	if len(raw) == 0 || raw[0] == nil {
+		return config, diags
+	}
+	retry := raw[0].(map[string]interface{})
+
+	if attempts, ok := retry["max_attempts"]; ok && attempts.(int) > 0 {
+		config.MaxAttempts = attempts.(int)
+	}
+	if statuses, ok := retry["retry_on_status"]; ok {
+		var codes []int
+		for _, s := range statuses.(*schema.Set).List() {
+			codes = append(codes, s.(int))
+		}
+		if len(codes) > 0 {
+			config.RetryOnStatus = codes
+		}
+	}
+	if backoff, ok := retry["initial_backoff"]; ok && backoff.(string) != "" {
+		d, err := time.ParseDuration(backoff.(string))
+		if err != nil {
+			return config, diag.FromErr(err)
+		}
+		config.InitialBackoff = d
+	}
+	if maxWait, ok := retry["max_wait"]; ok && maxWait.(string) != "" {
+		d, err := time.ParseDuration(maxWait.(string))
+		if err != nil {
+			return config, diag.FromErr(err)
+		}
+		config.MaxWait = d
+	}
+
+	return config, diags
+}
+
+// retryTransport retries requests failing with a transient error, honouring
+// the Retry-After header sent by the server when present.
+type retryTransport struct {
+	next   http.RoundTripper
+	config RetryConfig
+	name   string
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+func newRetryTransport(next http.RoundTripper, config RetryConfig, name string) *retryTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &retryTransport{
+		next:   next,
+		config: config,
+		name:   name,
+		sleep:  sleepWithContext,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if req.Body != nil && req.GetBody == nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		req.Body, _ = req.GetBody()
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("cannot rewind request body: %w", err)
+			}
+			req.Body = body
+		}
+
+		res, err := t.next.RoundTrip(req)
+		if attempt >= t.config.MaxAttempts || !t.shouldRetry(ctx, req, res, err) {
+			return res, err
+		}
+
+		wait := t.backoff(attempt, res)
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = res.Status
+			// drain the body so the underlying connection can be reused
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		tflog.Warn(ctx, fmt.Sprintf("%s request [%s %s] failed with [%s], retrying in %s (attempt %d of %d)", t.name, req.Method, req.URL, reason, wait, attempt+1, t.config.MaxAttempts))
+
+		if err := t.sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *retryTransport) shouldRetry(ctx context.Context, req *http.Request, res *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	// a request failing with a transport error may have reached the server, replaying it
+	// could for instance create a second API key, so only the safe ones are retried
+	if err != nil {
+		return isIdempotent(req.Method) || isDialError(err)
+	}
+	// a proxy may return a gateway error once Elasticsearch has handled the request
+	if isGatewayError(res.StatusCode) && !isIdempotent(req.Method) {
+		return false
+	}
+	for _, status := range t.config.RetryOnStatus {
+		if res.StatusCode == status {
+			return true
+		}
+	}
+	return false
+}
+
+func isGatewayError(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusGatewayTimeout
+}
+
+// isIdempotent checks whether the request can be replayed without side effects. PUT isn't,
+// since Elasticsearch creates some objects like API keys with PUT requests.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isDialError checks whether the request failed to connect, and was never sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// backoff returns how long to wait before the next attempt. A Retry-After header
+// takes precedence, otherwise an exponential backoff with jitter is used.
+// Both are capped by the configured max wait.
+func (t *retryTransport) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			return t.capWait(wait)
+		}
+	}
+
+	wait := t.config.InitialBackoff
+	for i := 1; i < attempt && wait < t.config.MaxWait; i++ {
+		wait *= 2
+	}
+	wait = t.capWait(wait)
+	// equal jitter: keep half of the wait and randomize the rest
+	if half := int64(wait / 2); half > 0 {
+		wait = time.Duration(half + rand.Int63n(half+1))
+	}
+	return wait
+}
+
+func (t *retryTransport) capWait(wait time.Duration) time.Duration {
+	if wait < 0 || (t.config.MaxWait > 0 && wait > t.config.MaxWait) {
+		return t.config.MaxWait
+	}
+	return wait
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,191 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		statuses      []int
+		maxAttempts   int
+		expectedCalls int
+		expectedCode  int
+	}{
+		{"succeeds without retry", []int{200}, 3, 1, 200},
+		{"retries throttled requests", []int{429, 503, 200}, 3, 3, 200},
+		{"gives up after max attempts", []int{503, 503, 503, 503}, 3, 3, 503},
+		{"does not retry client errors", []int{400, 200}, 3, 1, 400},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != `{"key":"value"}` {
+					t.Errorf("unexpected request body on attempt %d: %s", calls+1, body)
+				}
+				w.WriteHeader(tc.statuses[calls])
+				calls++
+			}))
+			defer server.Close()
+
+			config := defaultRetryConfig()
+			config.MaxAttempts = tc.maxAttempts
+			transport := newRetryTransport(http.DefaultTransport, config, "test")
+			transport.sleep = func(context.Context, time.Duration) error { return nil }
+
+			req, err := http.NewRequest(http.MethodPut, server.URL, io.NopCloser(strings.NewReader(`{"key":"value"}`)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode != tc.expectedCode {
+				t.Errorf("expected status %d, got %d", tc.expectedCode, res.StatusCode)
+			}
+			if calls != tc.expectedCalls {
+				t.Errorf("expected %d calls, got %d", tc.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	t.Parallel()
+
+	transport := newRetryTransport(nil, RetryConfig{InitialBackoff: time.Second, MaxWait: 10 * time.Second}, "test")
+
+	for attempt, max := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		wait := transport.backoff(attempt+1, nil)
+		if wait < max/2 || wait > max {
+			t.Errorf("attempt %d: expected backoff between %s and %s, got %s", attempt+1, max/2, max, wait)
+		}
+	}
+
+	res := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	if wait := transport.backoff(1, res); wait != 7*time.Second {
+		t.Errorf("expected Retry-After to be respected, got %s", wait)
+	}
+
+	res = &http.Response{Header: http.Header{"Retry-After": []string{"120"}}}
+	if wait := transport.backoff(1, res); wait != 10*time.Second {
+		t.Errorf("expected Retry-After to be capped by max wait, got %s", wait)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRetryTransportGatewayErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		method        string
+		status        int
+		expectedCalls int
+	}{
+		{"retries idempotent requests", http.MethodGet, http.StatusBadGateway, 3},
+		{"does not replay requests which may have been handled", http.MethodPost, http.StatusBadGateway, 1},
+		{"does not replay puts which may have been handled", http.MethodPut, http.StatusGatewayTimeout, 1},
+		{"retries requests rejected by the cluster", http.MethodPut, http.StatusServiceUnavailable, 3},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			next := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+				calls++
+				return &http.Response{StatusCode: tc.status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}, nil
+			})
+			transport := newRetryTransport(next, defaultRetryConfig(), "test")
+			transport.sleep = func(context.Context, time.Duration) error { return nil }
+
+			req, err := http.NewRequest(tc.method, "http://localhost:9200", strings.NewReader(`{"key":"value"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.StatusCode != tc.status {
+				t.Errorf("expected the status %d to be returned, got %d", tc.status, res.StatusCode)
+			}
+			if calls != tc.expectedCalls {
+				t.Errorf("expected %d calls, got %d", tc.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestRetryTransportErrors(t *testing.T) {
+	t.Parallel()
+
+	connectionReset := errors.New("connection reset by peer")
+	connectionRefused := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	tests := []struct {
+		name          string
+		method        string
+		err           error
+		expectedCalls int
+	}{
+		{"retries idempotent requests", http.MethodGet, connectionReset, 3},
+		{"retries deletions", http.MethodDelete, connectionReset, 3},
+		{"does not replay requests which may have been sent", http.MethodPost, connectionReset, 1},
+		{"does not replay puts which may have been sent", http.MethodPut, connectionReset, 1},
+		{"retries requests which were never sent", http.MethodPost, connectionRefused, 3},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			next := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+				calls++
+				return nil, tc.err
+			})
+			transport := newRetryTransport(next, defaultRetryConfig(), "test")
+			transport.sleep = func(context.Context, time.Duration) error { return nil }
+
+			req, err := http.NewRequest(tc.method, "http://localhost:9200", strings.NewReader(`{"key":"value"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := transport.RoundTrip(req); err == nil {
+				t.Error("expected the transport error to be returned")
+			}
+			if calls != tc.expectedCalls {
+				t.Errorf("expected %d calls, got %d", tc.expectedCalls, calls)
+			}
+		})
+	}
+}
@@ -8,143 +8,247 @@ import (
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	providerSchema "github.com/elastic/terraform-provider-elasticstack/internal/schema"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-func ResourceScript() *schema.Resource {
-	scriptSchema := map[string]*schema.Schema{
-		"script_id": {
-			Description: "Identifier for the stored script. Must be unique within the cluster.",
-			Type:        schema.TypeString,
-			Required:    true,
-			ForceNew:    true,
-		},
-		"lang": {
-			Description:  "Script language. For search templates, use `mustache`.",
-			Type:         schema.TypeString,
-			Required:     true,
-			ValidateFunc: validation.StringInSlice([]string{"painless", "expression", "mustache", "java"}, false),
-		},
-		"source": {
-			Description: "For scripts, a string containing the script. For search templates, an object containing the search template.",
-			Type:        schema.TypeString,
-			Required:    true,
-		},
-		"params": {
-			Description:      "Parameters for the script or search template.",
-			Type:             schema.TypeString,
-			Optional:         true,
-			DiffSuppressFunc: utils.DiffJsonSuppress,
-			ValidateFunc:     validation.StringIsJSON,
-		},
-		"context": {
-			Description: "Context in which the script or search template should run.",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
-	}
-	utils.AddConnectionSchema(scriptSchema)
+var (
+	_ resource.Resource                = &scriptResource{}
+	_ resource.ResourceWithConfigure   = &scriptResource{}
+	_ resource.ResourceWithImportState = &scriptResource{}
+)
 
-	return &schema.Resource{
-		Description: "Creates or updates a stored script or search template. See https://www.elastic.co/guide/en/elasticsearch/reference/current/create-stored-script-api.html",
+// NewScriptResource returns the stored script resource, implemented with the plugin framework.
+func NewScriptResource() resource.Resource {
+	return &scriptResource{}
+}
 
-		CreateContext: resourceScriptPut,
-		UpdateContext: resourceScriptPut,
-		ReadContext:   resourceScriptRead,
-		DeleteContext: resourceScriptDelete,
+type scriptResource struct {
+	client *clients.ApiClient
+}
 
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+type scriptData struct {
+	ID                      types.String                      `tfsdk:"id"`
+	ScriptID                types.String                      `tfsdk:"script_id"`
+	Lang                    types.String                      `tfsdk:"lang"`
+	Source                  types.String                      `tfsdk:"source"`
+	Params                  types.String                      `tfsdk:"params"`
+	Context                 types.String                      `tfsdk:"context"`
+	ElasticsearchConnection []clients.ElasticsearchConnection `tfsdk:"elasticsearch_connection"`
+}
 
-		Schema: scriptSchema,
+func (r *scriptResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_elasticsearch_script"
+}
+
+func (r *scriptResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates or updates a stored script or search template. See https://www.elastic.co/guide/en/elasticsearch/reference/current/create-stored-script-api.html",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this resource.",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"script_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier for the stored script. Must be unique within the cluster.",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"lang": schema.StringAttribute{
+				MarkdownDescription: "Script language. For search templates, use `mustache`.",
+				Required:            true,
+				Validators:          []validator.String{stringvalidator.OneOf("painless", "expression", "mustache", "java")},
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "For scripts, a string containing the script. For search templates, an object containing the search template.",
+				Required:            true,
+			},
+			"params": schema.StringAttribute{
+				MarkdownDescription: "Parameters for the script or search template.",
+				Optional:            true,
+				Validators:          []validator.String{utils.StringIsJSONValidator()},
+				PlanModifiers:       []planmodifier.String{utils.DiffJsonSuppressModifier()},
+			},
+			"context": schema.StringAttribute{
+				MarkdownDescription: "Context in which the script or search template should run.",
+				Optional:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"elasticsearch_connection": providerSchema.GetEsFWResourceConnectionBlock(),
+		},
 	}
 }
 
-func resourceScriptRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, diags := clients.NewApiClient(d, meta)
-	if diags.HasError() {
-		return diags
+func (r *scriptResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// the provider data is nil until the provider has been configured
+	if req.ProviderData == nil {
+		return
 	}
 
-	id := d.Id()
-	compId, diags := clients.CompositeIdFromStr(id)
-	if diags.HasError() {
-		return diags
+	client, ok := req.ProviderData.(*clients.ApiClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *clients.ApiClient, got %T", req.ProviderData))
+		return
 	}
+	r.client = client
+}
 
-	script, diags := elasticsearch.GetScript(ctx, client, compId.ResourceId)
-	if script == nil && diags == nil {
-		tflog.Warn(ctx, fmt.Sprintf(`Script "%s" not found, removing from state`, compId.ResourceId))
-		d.SetId("")
+func (r *scriptResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.put(ctx, req.Plan, &resp.State)...)
+}
+
+func (r *scriptResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.put(ctx, req.Plan, &resp.State)...)
+}
+
+func (r *scriptResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data scriptData
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if diags.HasError() {
-		return diags
+
+	found, diags := r.read(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
 	}
 
-	if err := d.Set("script_id", compId.ResourceId); err != nil {
-		return diag.FromErr(err)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *scriptResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data scriptData
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if err := d.Set("lang", script.Language); err != nil {
-		return diag.FromErr(err)
+
+	client, diags := clients.NewApiClientFromFrameworkResource(ctx, data.ElasticsearchConnection, r.client)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if err := d.Set("source", script.Source); err != nil {
-		return diag.FromErr(err)
+
+	compId, sdkDiags := clients.CompositeIdFromStr(data.ID.ValueString())
+	resp.Diagnostics.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	resp.Diagnostics.Append(utils.FrameworkDiagsFromSDK(elasticsearch.DeleteScript(ctx, client, compId.ResourceId))...)
+}
 
-	return diags
+func (r *scriptResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	compId, sdkDiags := r.client.ImportCompositeId(ctx, req.ID)
+	resp.Diagnostics.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), compId.String())...)
 }
 
-func resourceScriptPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, diags := clients.NewApiClient(d, meta)
+func (r *scriptResource) put(ctx context.Context, plan tfsdk.Plan, state *tfsdk.State) diag.Diagnostics {
+	var data scriptData
+	diags := plan.Get(ctx, &data)
 	if diags.HasError() {
 		return diags
 	}
 
-	scriptID := d.Get("script_id").(string)
-	id, diags := client.ID(ctx, scriptID)
+	client, d := clients.NewApiClientFromFrameworkResource(ctx, data.ElasticsearchConnection, r.client)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	scriptID := data.ScriptID.ValueString()
+	id, sdkDiags := client.ID(ctx, scriptID)
+	diags.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
 	if diags.HasError() {
 		return diags
 	}
 
 	script := models.Script{
 		ID:       scriptID,
-		Language: d.Get("lang").(string),
-		Source:   d.Get("source").(string),
+		Language: data.Lang.ValueString(),
+		Source:   data.Source.ValueString(),
 	}
-	if paramsJSON, ok := d.GetOk("params"); ok {
+	if paramsJSON := data.Params.ValueString(); paramsJSON != "" {
 		var params map[string]interface{}
-		bytes := []byte(paramsJSON.(string))
-		err := json.Unmarshal(bytes, &params)
-		if err != nil {
-			return diag.FromErr(err)
+		if err := json.Unmarshal([]byte(paramsJSON), &params); err != nil {
+			diags.AddError("Unable to parse script params", err.Error())
+			return diags
 		}
 		script.Params = params
 	}
-	if scriptContext, ok := d.GetOk("context"); ok {
-		script.Context = scriptContext.(string)
+	if scriptContext := data.Context.ValueString(); scriptContext != "" {
+		script.Context = scriptContext
 	}
-	if diags := elasticsearch.PutScript(ctx, client, &script); diags.HasError() {
+	diags.Append(utils.FrameworkDiagsFromSDK(elasticsearch.PutScript(ctx, client, &script))...)
+	if diags.HasError() {
 		return diags
 	}
 
-	d.SetId(id.String())
-	return resourceScriptRead(ctx, d, meta)
+	data.ID = types.StringValue(id.String())
+	found, d := r.read(ctx, &data)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	if !found {
+		diags.AddError("Script not found", fmt.Sprintf(`Script "%s" was not found after being created`, scriptID))
+		return diags
+	}
+
+	diags.Append(state.Set(ctx, &data)...)
+	return diags
 }
 
-func resourceScriptDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, diags := clients.NewApiClient(d, meta)
+// read refreshes the data from the cluster and reports whether the script still exists.
+func (r *scriptResource) read(ctx context.Context, data *scriptData) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	client, d := clients.NewApiClientFromFrameworkResource(ctx, data.ElasticsearchConnection, r.client)
+	diags.Append(d...)
 	if diags.HasError() {
-		return diags
+		return false, diags
 	}
 
-	compId, diags := clients.CompositeIdFromStr(d.Id())
+	compId, sdkDiags := clients.CompositeIdFromStr(data.ID.ValueString())
+	diags.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
 	if diags.HasError() {
-		return diags
+		return false, diags
 	}
-	return elasticsearch.DeleteScript(ctx, client, compId.ResourceId)
+
+	script, sdkDiags := elasticsearch.GetScript(ctx, client, compId.ResourceId)
+	diags.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
+	if diags.HasError() {
+		return false, diags
+	}
+	if script == nil {
+		tflog.Warn(ctx, fmt.Sprintf(`Script "%s" not found, removing from state`, compId.ResourceId))
+		return false, diags
+	}
+
+	data.ScriptID = types.StringValue(compId.ResourceId)
+	data.Lang = types.StringValue(script.Language)
+	data.Source = types.StringValue(script.Source)
+
+	return true, diags
 }
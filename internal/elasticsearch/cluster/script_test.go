@@ -37,6 +37,19 @@ func TestAccResourceScript(t *testing.T) {
 					resource.TestCheckResourceAttr("elasticstack_elasticsearch_script.test", "params", `{"changed_modifier":2}`),
 				),
 			},
+			{
+				// reformatted params are equivalent and don't update the script
+				Config:   testAccScriptUpdateReformatted(scriptID),
+				PlanOnly: true,
+			},
+			{
+				Config:                  testAccScriptUpdate(scriptID),
+				ResourceName:            "elasticstack_elasticsearch_script.test",
+				ImportState:             true,
+				ImportStateId:           scriptID,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"context", "params"},
+			},
 		},
 	})
 }
@@ -94,6 +107,25 @@ resource "elasticstack_elasticsearch_script" "test" {
 	`, id)
 }
 
+func testAccScriptUpdateReformatted(id string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_script" "test" {
+  script_id = "%s"
+  lang      = "painless"
+  source    = "Math.log(_score * 4) + params['changed_modifier']"
+  params    = <<-EOT
+    {
+      "changed_modifier": 2
+    }
+  EOT
+}
+	`, id)
+}
+
 func testAccSearchTemplateCreate(id string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
@@ -78,7 +78,7 @@ func ResourceSettings() *schema.Resource {
 		DeleteContext: resourceClusterSettingsDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStateContext,
 		},
 
 		Schema: settingsSchema,
@@ -147,7 +147,7 @@ func ResourceSlm() *schema.Resource {
 		DeleteContext: resourceSlmDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStateContext,
 		},
 
 		Schema: slmSchema,
@@ -308,7 +308,7 @@ func ResourceSnapshotRepository() *schema.Resource {
 		DeleteContext: resourceSnapRepoDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStateContext,
 		},
 
 		Schema: snapRepoSchema,
@@ -84,7 +84,7 @@ func ResourceEnrichPolicy() *schema.Resource {
 		DeleteContext: resourceEnrichPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStateContext,
 		},
 
 		Schema: policySchema,
@@ -0,0 +1,255 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceAlias() *schema.Resource {
+	aliasSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "Name of the alias.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			ValidateFunc: validation.All(
+				validation.StringLenBetween(1, 255),
+				validation.StringNotInSlice([]string{".", ".."}, true),
+				validation.StringMatch(regexp.MustCompile(`^[^-_+]`), "cannot start with -, _, +"),
+				validation.StringMatch(regexp.MustCompile(`^[a-z0-9!$%&'()+.;=@[\]^{}~_-]+$`), "must contain lower case alphanumeric characters and selected punctuation, see: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-add-alias.html#add-alias-api-path-params"),
+			),
+		},
+		"is_hidden": {
+			Description: "If true, the alias is hidden. All the indices of the alias must have the same value.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"index": {
+			Description: "Indices the alias points to.",
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "Name of the index.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"is_write_index": {
+						Description: "If true, the index is the write index for the alias. At most one index of the alias can be the write index.",
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+					},
+					"filter": {
+						Description:      "Query used to limit documents the alias can access through this index.",
+						Type:             schema.TypeString,
+						Optional:         true,
+						Default:          "",
+						DiffSuppressFunc: utils.DiffJsonSuppress,
+						ValidateFunc:     validation.StringIsJSON,
+					},
+					"index_routing": {
+						Description: "Value used to route indexing operations to a specific shard.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     "",
+					},
+					"search_routing": {
+						Description: "Value used to route search operations to a specific shard.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     "",
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(aliasSchema)
+
+	return &schema.Resource{
+		Description: "Manages an alias pointing to one or many indices. Changes are applied atomically in a single call to the aliases API, moving an alias from an index to another never leaves it without index. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-aliases.html",
+
+		CreateContext: resourceAliasPut,
+		UpdateContext: resourceAliasPut,
+		ReadContext:   resourceAliasRead,
+		DeleteContext: resourceAliasDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: clients.ImportStateContext,
+		},
+
+		Schema: aliasSchema,
+	}
+}
+
+func resourceAliasPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	aliasName := d.Get("name").(string)
+	id, diags := client.ID(ctx, aliasName)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliases, diags := expandAliasIndices(d, aliasName)
+	if diags.HasError() {
+		return diags
+	}
+
+	var actions []models.AliasAction
+	// remove the alias from the indices it doesn't point to anymore in the same call
+	oldIndices, _ := d.GetChange("index")
+	for _, i := range oldIndices.(*schema.Set).List() {
+		index := i.(map[string]interface{})["name"].(string)
+		if _, ok := aliases[index]; !ok {
+			actions = append(actions, models.AliasAction{Type: "remove", Index: index, Alias: models.IndexAlias{Name: aliasName}})
+		}
+	}
+	for _, index := range sortedKeys(aliases) {
+		actions = append(actions, models.AliasAction{Type: "add", Index: index, Alias: aliases[index]})
+	}
+
+	if diags := elasticsearch.UpdateAliases(ctx, client, actions); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceAliasRead(ctx, d, meta)
+}
+
+func resourceAliasRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	aliasName := compId.ResourceId
+
+	aliases, diags := elasticsearch.GetAlias(ctx, client, aliasName)
+	if aliases == nil && diags == nil {
+		tflog.Warn(ctx, fmt.Sprintf(`Alias "%s" not found, removing from state`, aliasName))
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	isHidden := false
+	indices := make([]interface{}, 0, len(aliases))
+	for _, index := range sortedKeys(aliases) {
+		alias := aliases[index]
+		isHidden = isHidden || alias.IsHidden
+		a, diags := FlattenIndexAlias(index, alias)
+		if diags.HasError() {
+			return diags
+		}
+		i := a.(map[string]interface{})
+		delete(i, "is_hidden")
+		delete(i, "routing")
+		indices = append(indices, i)
+	}
+
+	if err := d.Set("name", aliasName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_hidden", isHidden); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("index", indices); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceAliasDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	aliasName := compId.ResourceId
+
+	// the alias is removed from the indices it currently points to, some of the indices in the state may be gone
+	aliases, diags := elasticsearch.GetAlias(ctx, client, aliasName)
+	if diags.HasError() {
+		return diags
+	}
+	var actions []models.AliasAction
+	for _, index := range sortedKeys(aliases) {
+		actions = append(actions, models.AliasAction{Type: "remove", Index: index, Alias: models.IndexAlias{Name: aliasName}})
+	}
+	if len(actions) > 0 {
+		if diags := elasticsearch.UpdateAliases(ctx, client, actions); diags.HasError() {
+			return diags
+		}
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// expandAliasIndices returns the definitions of the alias keyed by index name.
+func expandAliasIndices(d *schema.ResourceData, aliasName string) (map[string]models.IndexAlias, diag.Diagnostics) {
+	isHidden := d.Get("is_hidden").(bool)
+	aliases := make(map[string]models.IndexAlias)
+	var writeIndices []string
+	for _, i := range d.Get("index").(*schema.Set).List() {
+		index := i.(map[string]interface{})
+		indexName := index["name"].(string)
+		if _, ok := aliases[indexName]; ok {
+			return nil, diag.Errorf(`The index "%s" is defined more than once in alias "%s"`, indexName, aliasName)
+		}
+
+		alias, diags := ExpandIndexAlias(map[string]interface{}{
+			"name":           aliasName,
+			"filter":         index["filter"],
+			"index_routing":  index["index_routing"],
+			"is_hidden":      isHidden,
+			"is_write_index": index["is_write_index"],
+			"routing":        "",
+			"search_routing": index["search_routing"],
+		})
+		if diags.HasError() {
+			return nil, diags
+		}
+		if alias.IsWriteIndex {
+			writeIndices = append(writeIndices, indexName)
+		}
+		aliases[indexName] = *alias
+	}
+	if len(writeIndices) > 1 {
+		sort.Strings(writeIndices)
+		return nil, diag.Errorf(`Alias "%s" can only have one write index, found: %v`, aliasName, writeIndices)
+	}
+	return aliases, nil
+}
@@ -0,0 +1,161 @@
+package index_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceAlias(t *testing.T) {
+	// generate a random name
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceAliasDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceAlias(name, `
+  index {
+    name           = elasticstack_elasticsearch_index.v1.name
+    is_write_index = true
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_alias.test", "name", name),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_alias.test", "index.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("elasticstack_elasticsearch_index_alias.test", "index.*", map[string]string{
+						"name":           name + "-v1",
+						"is_write_index": "true",
+					}),
+				),
+			},
+			{
+				Config: testAccResourceAlias(name, `
+  index {
+    name   = elasticstack_elasticsearch_index.v1.name
+    filter = jsonencode({ term = { "user.id" = "kimchy" } })
+  }
+
+  index {
+    name           = elasticstack_elasticsearch_index.v2.name
+    is_write_index = true
+    index_routing  = "1"
+    search_routing = "1,2"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_alias.test", "index.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("elasticstack_elasticsearch_index_alias.test", "index.*", map[string]string{
+						"name":           name + "-v1",
+						"is_write_index": "false",
+						"filter":         `{"term":{"user.id":"kimchy"}}`,
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("elasticstack_elasticsearch_index_alias.test", "index.*", map[string]string{
+						"name":           name + "-v2",
+						"is_write_index": "true",
+						"index_routing":  "1",
+						"search_routing": "1,2",
+					}),
+				),
+			},
+			{
+				Config: testAccResourceAlias(name, `
+  index {
+    name           = elasticstack_elasticsearch_index.v2.name
+    is_write_index = true
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_alias.test", "index.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("elasticstack_elasticsearch_index_alias.test", "index.*", map[string]string{
+						"name":           name + "-v2",
+						"is_write_index": "true",
+					}),
+				),
+			},
+			{
+				ResourceName:      "elasticstack_elasticsearch_index_alias.test",
+				ImportState:       true,
+				ImportStateId:     name,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccResourceAlias(name, `
+  index {
+    name           = elasticstack_elasticsearch_index.v1.name
+    is_write_index = true
+  }
+
+  index {
+    name           = elasticstack_elasticsearch_index.v2.name
+    is_write_index = true
+  }`),
+				ExpectError: regexp.MustCompile(`can only have one write index`),
+			},
+		},
+	})
+}
+
+func testAccResourceAlias(name, indices string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "v1" {
+  name                = "%[1]s-v1"
+  deletion_protection = false
+
+  lifecycle {
+    ignore_changes = [alias]
+  }
+}
+
+resource "elasticstack_elasticsearch_index" "v2" {
+  name                = "%[1]s-v2"
+  deletion_protection = false
+
+  lifecycle {
+    ignore_changes = [alias]
+  }
+}
+
+resource "elasticstack_elasticsearch_index_alias" "test" {
+  name = "%[1]s"
+%[2]s
+}
+`, name, indices)
+}
+
+func checkResourceAliasDestroy(s *terraform.State) error {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_index_alias" {
+			continue
+		}
+		compId, _ := clients.CompositeIdFromStr(rs.Primary.ID)
+
+		esClient, err := client.GetESClient()
+		if err != nil {
+			return err
+		}
+		res, err := esClient.Indices.GetAlias(esClient.Indices.GetAlias.WithName(compId.ResourceId))
+		if err != nil {
+			return err
+		}
+
+		if res.StatusCode != 404 {
+			return fmt.Errorf("Alias (%s) still exists", compId.ResourceId)
+		}
+	}
+	return nil
+}
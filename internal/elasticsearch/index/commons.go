@@ -2,6 +2,7 @@ package index
 
 import (
 	"encoding/json"
+	"sort"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -80,3 +81,13 @@ func FlattenIndexAlias(name string, alias models.IndexAlias) (interface{}, diag.
 
 	return a, diags
 }
+
+// sortedKeys returns the keys of the map in alphabetical order.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -127,7 +127,7 @@ func ResourceComponentTemplate() *schema.Resource {
 		DeleteContext: resourceComponentTemplateDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStateContext,
 		},
 
 		Schema: componentTemplateSchema,
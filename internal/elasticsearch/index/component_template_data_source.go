@@ -0,0 +1,50 @@
+package index
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceComponentTemplate() *schema.Resource {
+	templateSchema := utils.DataSourceSchemaFromResourceSchema(ResourceComponentTemplate().Schema)
+	templateSchema["name"] = &schema.Schema{
+		Description: "Name of the component template.",
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+
+	utils.AddConnectionSchema(templateSchema)
+
+	return &schema.Resource{
+		Description: "Gets a component template, e.g. a built-in template like `logs@settings`, which doesn't need to be managed by Terraform. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/getting-component-templates.html",
+
+		ReadContext: dataSourceComponentTemplateRead,
+
+		Schema: templateSchema,
+	}
+}
+
+func dataSourceComponentTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	name := d.Get("name").(string)
+	id, diags := client.ID(ctx, name)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	if diags := resourceComponentTemplateRead(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+	if d.Id() == "" {
+		return diag.Errorf(`Component template "%s" not found`, name)
+	}
+	return diags
+}
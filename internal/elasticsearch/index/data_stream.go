@@ -111,7 +111,7 @@ func ResourceDataStream() *schema.Resource {
 		DeleteContext: resourceDataStreamDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStateContext,
 		},
 
 		Schema: dataStreamSchema,
@@ -10,6 +10,7 @@ import (
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -107,9 +108,11 @@ func ResourceIlm() *schema.Resource {
 		DeleteContext: resourceIlmDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStateContext,
 		},
 
+		CustomizeDiff: versionutils.CheckCapabilities(ilmResourceName),
+
 		Schema: ilmSchema,
 	}
 }
@@ -534,20 +537,21 @@ func expandPhase(p map[string]interface{}, serverVersion *version.Version) (*mod
 	return &phase, diags
 }
 
-var RolloverMinConditionsMinSupportedVersion = version.Must(version.NewVersion("8.4.0"))
+const ilmResourceName = "elasticstack_elasticsearch_index_lifecycle"
+
 var ilmActionSettingOptions = map[string]struct {
 	skipEmptyCheck bool
 	def            interface{}
 	minVersion     *version.Version
 }{
 	"number_of_replicas":     {skipEmptyCheck: true},
-	"total_shards_per_node":  {skipEmptyCheck: true, def: -1, minVersion: version.Must(version.NewVersion("7.16.0"))},
+	"total_shards_per_node":  {skipEmptyCheck: true, def: -1, minVersion: versionutils.MinVersion(ilmResourceName, "warm.allocate.total_shards_per_node")},
 	"priority":               {skipEmptyCheck: true},
-	"min_age":                {def: "", minVersion: RolloverMinConditionsMinSupportedVersion},
-	"min_docs":               {def: 0, minVersion: RolloverMinConditionsMinSupportedVersion},
-	"min_size":               {def: "", minVersion: RolloverMinConditionsMinSupportedVersion},
-	"min_primary_shard_size": {def: "", minVersion: RolloverMinConditionsMinSupportedVersion},
-	"min_primary_shard_docs": {def: 0, minVersion: RolloverMinConditionsMinSupportedVersion},
+	"min_age":                {def: "", minVersion: versionutils.MinVersion(ilmResourceName, "hot.rollover.min_age")},
+	"min_docs":               {def: 0, minVersion: versionutils.MinVersion(ilmResourceName, "hot.rollover.min_docs")},
+	"min_size":               {def: "", minVersion: versionutils.MinVersion(ilmResourceName, "hot.rollover.min_size")},
+	"min_primary_shard_size": {def: "", minVersion: versionutils.MinVersion(ilmResourceName, "hot.rollover.min_primary_shard_size")},
+	"min_primary_shard_docs": {def: 0, minVersion: versionutils.MinVersion(ilmResourceName, "hot.rollover.min_primary_shard_docs")},
 }
 
 func expandAction(a []interface{}, serverVersion *version.Version, settings ...string) (map[string]interface{}, diag.Diagnostics) {
@@ -0,0 +1,50 @@
+package index
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceIlm() *schema.Resource {
+	policySchema := utils.DataSourceSchemaFromResourceSchema(ResourceIlm().Schema)
+	policySchema["name"] = &schema.Schema{
+		Description: "Name of the ILM policy.",
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+
+	utils.AddConnectionSchema(policySchema)
+
+	return &schema.Resource{
+		Description: "Gets an index lifecycle policy, e.g. a built-in policy like `logs`, which doesn't need to be managed by Terraform. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-get-lifecycle.html",
+
+		ReadContext: dataSourceIlmRead,
+
+		Schema: policySchema,
+	}
+}
+
+func dataSourceIlmRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	name := d.Get("name").(string)
+	id, diags := client.ID(ctx, name)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	if diags := resourceIlmRead(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+	if d.Id() == "" {
+		return diag.Errorf(`ILM policy "%s" not found`, name)
+	}
+	return diags
+}
@@ -0,0 +1,78 @@
+package index_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceIlm(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceIlm(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_lifecycle.test", "name", name),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_lifecycle.test", "hot.0.min_age", "1h"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_lifecycle.test", "hot.0.rollover.0.max_age", "1d"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_lifecycle.test", "hot.0.set_priority.0.priority", "10"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_lifecycle.test", "delete.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_lifecycle.test", "warm.#", "0"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_index_lifecycle.test", "modified_date"),
+				),
+			},
+			{
+				Config: `
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_index_lifecycle" "missing" {
+  name = "missing-policy"
+}
+`,
+				ExpectError: regexp.MustCompile(`ILM policy "missing-policy" not found`),
+			},
+		},
+	})
+}
+
+func testAccDataSourceIlm(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "test" {
+  name = "%s"
+
+  hot {
+    min_age = "1h"
+
+    set_priority {
+      priority = 10
+    }
+
+    rollover {
+      max_age = "1d"
+    }
+  }
+
+  delete {
+    delete {}
+  }
+}
+
+data "elasticstack_elasticsearch_index_lifecycle" "test" {
+  name = elasticstack_elasticsearch_index_lifecycle.test.name
+}
+	`, name)
+}
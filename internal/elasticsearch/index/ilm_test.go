@@ -6,16 +6,12 @@ import (
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
-	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/index"
 	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
-	"github.com/hashicorp/go-version"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
-var totalShardsPerNodeVersionLimit = version.Must(version.NewVersion("7.16.0"))
-
 func TestAccResourceILM(t *testing.T) {
 	// generate a random policy name
 	policyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
@@ -62,7 +58,7 @@ func TestAccResourceILM(t *testing.T) {
 				),
 			},
 			{
-				SkipFunc: versionutils.CheckIfVersionIsUnsupported(totalShardsPerNodeVersionLimit),
+				SkipFunc: versionutils.CheckIfNotSupported("elasticstack_elasticsearch_index_lifecycle", "warm.allocate.total_shards_per_node"),
 				Config:   testAccResourceILMTotalShardsPerNode(policyName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_lifecycle.test", "name", policyName),
@@ -89,7 +85,7 @@ func TestAccResourceILMRolloverConditions(t *testing.T) {
 		ProtoV5ProviderFactories: acctest.Providers,
 		Steps: []resource.TestStep{
 			{
-				SkipFunc: versionutils.CheckIfVersionIsUnsupported(index.RolloverMinConditionsMinSupportedVersion),
+				SkipFunc: versionutils.CheckIfNotSupported("elasticstack_elasticsearch_index_lifecycle", "hot.rollover.min_age"),
 				Config:   testAccResourceILMCreateWithRolloverConditions(policyName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_lifecycle.test_rollover", "name", policyName),
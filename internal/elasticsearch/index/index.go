@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -80,6 +79,21 @@ var (
 		"indexing.slowlog.source":                schema.TypeString,
 	}
 	allSettingsKeys = map[string]schema.ValueType{}
+	// closedIndexSettingsKeys are the static settings which can be updated once the index is closed
+	closedIndexSettingsKeys = []string{
+		"codec",
+		"load_fixed_bitset_filters_eagerly",
+		"shard.check_on_startup",
+		"mapping.coerce",
+	}
+	// analysisSettingsKeys maps the analysis fields to the analysis settings they define
+	analysisSettingsKeys = map[string]string{
+		"analysis_analyzer":    "analyzer",
+		"analysis_tokenizer":   "tokenizer",
+		"analysis_char_filter": "char_filter",
+		"analysis_filter":      "filter",
+		"analysis_normalizer":  "normalizer",
+	}
 )
 
 var includeTypeNameMinUnsupportedVersion = version.Must(version.NewVersion("8.0.0"))
@@ -127,8 +141,7 @@ func ResourceIndex() *schema.Resource {
 		},
 		"codec": {
 			Type:         schema.TypeString,
-			Description:  "The `default` value compresses stored data with LZ4 compression, but this can be set to `best_compression` which uses DEFLATE for a higher compression ratio. This can be set only on creation.",
-			ForceNew:     true,
+			Description:  "The `default` value compresses stored data with LZ4 compression, but this can be set to `best_compression` which uses DEFLATE for a higher compression ratio. Changing it recreates the index, unless the index is closed or `close_to_update_static_settings` is set.",
 			Optional:     true,
 			ValidateFunc: validation.StringInSlice([]string{"best_compression"}, false),
 		},
@@ -140,14 +153,12 @@ func ResourceIndex() *schema.Resource {
 		},
 		"load_fixed_bitset_filters_eagerly": {
 			Type:        schema.TypeBool,
-			Description: "Indicates whether cached filters are pre-loaded for nested queries. This can be set only on creation.",
-			ForceNew:    true,
+			Description: "Indicates whether cached filters are pre-loaded for nested queries. Changing it recreates the index, unless the index is closed or `close_to_update_static_settings` is set.",
 			Optional:    true,
 		},
 		"shard_check_on_startup": {
 			Type:         schema.TypeString,
-			Description:  "Whether or not shards should be checked for corruption before opening. When corruption is detected, it will prevent the shard from being opened. Accepts `false`, `true`, `checksum`.",
-			ForceNew:     true,
+			Description:  "Whether or not shards should be checked for corruption before opening. When corruption is detected, it will prevent the shard from being opened. Accepts `false`, `true`, `checksum`. Changing it recreates the index, unless the index is closed or `close_to_update_static_settings` is set.",
 			Optional:     true,
 			ValidateFunc: validation.StringInSlice([]string{"false", "true", "checksum"}, false),
 		},
@@ -168,8 +179,7 @@ func ResourceIndex() *schema.Resource {
 		},
 		"mapping_coerce": {
 			Type:        schema.TypeBool,
-			Description: "Set index level coercion setting that is applied to all mapping types.",
-			ForceNew:    true,
+			Description: "Set index level coercion setting that is applied to all mapping types. Changing it recreates the index, unless the index is closed or `close_to_update_static_settings` is set.",
 			Optional:    true,
 		},
 		// Dynamic settings that can be changed at runtime
@@ -395,37 +405,42 @@ func ResourceIndex() *schema.Resource {
 			Description: "Set the number of characters of the `_source` to include in the slowlog lines, `false` or `0` will skip logging the source entirely and setting it to `true` will log the entire source regardless of size. The original `_source` is reformatted by default to make sure that it fits on a single log line.",
 			Optional:    true,
 		},
-		// To change analyzer setting, the index must be closed, updated, and then reopened but it can't be handled in terraform.
-		// We raise error when they are tried to be updated instead of setting ForceNew not to have unexpected deletion.
+		// The analysis settings can only be updated on a closed index, the plan fails when they are changed on an
+		// open index instead of setting ForceNew not to have unexpected deletion.
 		"analysis_analyzer": {
-			Type:         schema.TypeString,
-			Description:  "A JSON string describing the analyzers applied to the index.",
-			Optional:     true,
-			ValidateFunc: validation.StringIsJSON,
+			Type:             schema.TypeString,
+			Description:      "A JSON string describing the analyzers applied to the index. It can only be updated when the index is closed or `close_to_update_static_settings` is set.",
+			Optional:         true,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+			ValidateFunc:     validation.StringIsJSON,
 		},
 		"analysis_tokenizer": {
-			Type:         schema.TypeString,
-			Description:  "A JSON string describing the tokenizers applied to the index.",
-			Optional:     true,
-			ValidateFunc: validation.StringIsJSON,
+			Type:             schema.TypeString,
+			Description:      "A JSON string describing the tokenizers applied to the index. It can only be updated when the index is closed or `close_to_update_static_settings` is set.",
+			Optional:         true,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+			ValidateFunc:     validation.StringIsJSON,
 		},
 		"analysis_char_filter": {
-			Type:         schema.TypeString,
-			Description:  "A JSON string describing the char_filters applied to the index.",
-			Optional:     true,
-			ValidateFunc: validation.StringIsJSON,
+			Type:             schema.TypeString,
+			Description:      "A JSON string describing the char_filters applied to the index. It can only be updated when the index is closed or `close_to_update_static_settings` is set.",
+			Optional:         true,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+			ValidateFunc:     validation.StringIsJSON,
 		},
 		"analysis_filter": {
-			Type:         schema.TypeString,
-			Description:  "A JSON string describing the filters applied to the index.",
-			Optional:     true,
-			ValidateFunc: validation.StringIsJSON,
+			Type:             schema.TypeString,
+			Description:      "A JSON string describing the filters applied to the index. It can only be updated when the index is closed or `close_to_update_static_settings` is set.",
+			Optional:         true,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+			ValidateFunc:     validation.StringIsJSON,
 		},
 		"analysis_normalizer": {
-			Type:         schema.TypeString,
-			Description:  "A JSON string describing the normalizers applied to the index.",
-			Optional:     true,
-			ValidateFunc: validation.StringIsJSON,
+			Type:             schema.TypeString,
+			Description:      "A JSON string describing the normalizers applied to the index. It can only be updated when the index is closed or `close_to_update_static_settings` is set.",
+			Optional:         true,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+			ValidateFunc:     validation.StringIsJSON,
 		},
 		"alias": {
 			Description: "Aliases for the index.",
@@ -483,8 +498,9 @@ func ResourceIndex() *schema.Resource {
 			Description: `Mapping for fields in the index.
 If specified, this mapping can include: field names, [field data types](https://www.elastic.co/guide/en/elasticsearch/reference/current/mapping-types.html), [mapping parameters](https://www.elastic.co/guide/en/elasticsearch/reference/current/mapping-params.html).
 **NOTE:**
-- Changing datatypes in the existing _mappings_ will force index to be re-created.
+- Changing datatypes, or mapping parameters which can't be updated like ` + "`analyzer`" + `, ` + "`index`" + ` or ` + "`doc_values`" + `, in the existing _mappings_ will force index to be re-created. The plan fails instead when ` + "`deletion_protection`" + ` is enabled.
 - Removing field will be ignored by default same as elasticsearch. You need to recreate the index to remove field completely.
+- Dynamic templates and runtime fields are updated in place, removing them from the mappings removes them from the index.
 `,
 			Type:             schema.TypeString,
 			Optional:         true,
@@ -525,11 +541,51 @@ If specified, this mapping can include: field names, [field data types](https://
 				},
 			},
 		},
+		"mapping_migration": {
+			Description: "Migrates the documents to a new index when the mappings can't be updated in place, instead of recreating the index and losing its data. The new index is named after `name` with an incremented suffix, e.g. `my-index-000002`. The documents are copied with the reindex API, then the aliases of the index are moved to the new index and the old index is replaced by an alias named after `name` atomically. The migration isn't free of downtime: the writes to the index are rejected while the documents are copied, which can last up to `timeout`, and unblocked if the migration fails. The reads are served by the old index until the aliases are moved.",
+			Type:        schema.TypeList,
+			MaxItems:    1,
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"pipeline": {
+						Description: "Ingest pipeline applied to the documents copied to the new index.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"timeout": {
+						Description:  "Period to wait for the documents to be copied to the new index, the reindex task is canceled once it expires. Defaults to `1h`.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "1h",
+						ValidateFunc: utils.StringIsDuration,
+					},
+				},
+			},
+		},
+		"concrete_name": {
+			Description: "Name of the concrete index. It differs from `name` once the index has been migrated by `mapping_migration`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
 		"settings_raw": {
 			Description: "All raw settings fetched from the cluster.",
 			Type:        schema.TypeString,
 			Computed:    true,
 		},
+		"state": {
+			Type:         schema.TypeString,
+			Description:  "State of the index: `open`, or `closed` to block the reads and writes. A closed index keeps its data and settings but can't be searched nor written to, and its static settings can be updated.",
+			Optional:     true,
+			Default:      "open",
+			ValidateFunc: validation.StringInSlice([]string{"open", "closed"}, false),
+		},
+		"close_to_update_static_settings": {
+			Type:        schema.TypeBool,
+			Description: "If true, the open index is closed to update its static settings, like the analysis settings or `codec`, and reopened afterwards, waiting for `wait_for_active_shards`. The index can't be searched nor written to in the meantime. Otherwise, changing these settings recreates the index, or fails the plan for the analysis settings.",
+			Optional:    true,
+			Default:     false,
+		},
 		"deletion_protection": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -576,21 +632,23 @@ If specified, this mapping can include: field names, [field data types](https://
 
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				// first populate what we can with Read
-				diags := resourceIndexRead(ctx, d, m)
-				if diags.HasError() {
-					return nil, fmt.Errorf("unable to import requested index")
-				}
-
 				client, diags := clients.NewApiClient(d, m)
 				if diags.HasError() {
 					return nil, fmt.Errorf("Unabled to create API client %v", diags)
 				}
-				compId, diags := clients.CompositeIdFromStr(d.Id())
+				compId, diags := client.ImportCompositeId(ctx, d.Id())
+				if diags.HasError() {
+					return nil, fmt.Errorf("failed to parse provided ID: %v", diags)
+				}
+				d.SetId(compId.String())
+
+				// first populate what we can with Read
+				diags = resourceIndexRead(ctx, d, m)
 				if diags.HasError() {
-					return nil, fmt.Errorf("failed to parse provided ID")
+					return nil, fmt.Errorf("unable to import requested index")
 				}
-				indexName := compId.ResourceId
+
+				indexName := d.Get("concrete_name").(string)
 				index, diags := elasticsearch.GetIndex(ctx, client, indexName)
 				if diags.HasError() {
 					return nil, fmt.Errorf("failed to get an ES Index")
@@ -599,28 +657,14 @@ If specified, this mapping can include: field names, [field data types](https://
 				// check the settings and import those as well
 				if index.Settings != nil {
 					for key, typ := range allSettingsKeys {
-						var value interface{}
-						if v, ok := index.Settings[key]; ok {
-							value = v
-						} else if v, ok := index.Settings["index."+key]; ok {
-							value = v
-						} else {
+						value, ok := lookupIndexSetting(index.Settings, key)
+						if !ok {
 							tflog.Warn(ctx, fmt.Sprintf("setting '%s' is not currently managed by terraform provider and has been ignored", key))
 							continue
 						}
-						switch typ {
-						case schema.TypeInt:
-							v, err := strconv.Atoi(value.(string))
-							if err != nil {
-								return nil, fmt.Errorf("failed to convert setting '%s' value %v to int: %w", key, value, err)
-							}
-							value = v
-						case schema.TypeBool:
-							v, err := strconv.ParseBool(value.(string))
-							if err != nil {
-								return nil, fmt.Errorf("failed to convert setting '%s' value %v to bool: %w", key, value, err)
-							}
-							value = v
+						value, err := normalizeIndexSetting(key, typ, value)
+						if err != nil {
+							return nil, err
 						}
 						if err := d.Set(utils.ConvertSettingsKeyToTFFieldKey(key), value); err != nil {
 							return nil, err
@@ -631,35 +675,65 @@ If specified, this mapping can include: field names, [field data types](https://
 			},
 		},
 
-		CustomizeDiff: customdiff.ForceNewIfChange("mappings", func(ctx context.Context, old, new, meta interface{}) bool {
-			o := make(map[string]interface{})
-			if err := json.NewDecoder(strings.NewReader(old.(string))).Decode(&o); err != nil {
-				return true
-			}
-			n := make(map[string]interface{})
-			if err := json.NewDecoder(strings.NewReader(new.(string))).Decode(&n); err != nil {
-				return true
-			}
-			tflog.Trace(ctx, "mappings custom diff old = %+v new = %+v", o, n)
-
-			// if old defined we must check if the type of the existing fields were changed
-			if oldProps, ok := o["properties"]; ok {
-				newProps, ok := n["properties"]
-				// if the old has props but new one not, immediately force new resource
-				if !ok {
-					return true
-				}
-				return IsMappingForceNewRequired(ctx, oldProps.(map[string]interface{}), newProps.(map[string]interface{}))
-			}
-
-			// if all check passed, we can update the map
-			return false
-		}),
+		CustomizeDiff: customdiff.All(resourceIndexMappingsDiff, resourceIndexStaticSettingsDiff),
 
 		Schema: indexSchema,
 	}
 }
 
+// resourceIndexMappingsDiff recreates the index when the mappings can't be updated in place, or migrates it
+// to a new index when `mapping_migration` is enabled. The plan fails when the index is protected from deletion.
+func resourceIndexMappingsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("mappings") {
+		return nil
+	}
+	old, new := d.GetChange("mappings")
+	changes := mappingsChangesRequiringNewIndex(ctx, old.(string), new.(string))
+	if len(changes) == 0 {
+		return nil
+	}
+	if len(d.Get("mapping_migration").([]interface{})) > 0 {
+		tflog.Info(ctx, fmt.Sprintf("mappings changes %v require migrating the index", changes))
+		return d.SetNewComputed("concrete_name")
+	}
+	if d.Get("deletion_protection").(bool) {
+		return fmt.Errorf("the mappings changes [%s] can't be applied to the existing index and require recreating it, which deletion_protection prevents. Set deletion_protection = false, or configure mapping_migration to migrate the documents to a new index", strings.Join(changes, ", "))
+	}
+	tflog.Info(ctx, fmt.Sprintf("mappings changes %v require recreating the index", changes))
+	return d.ForceNew("mappings")
+}
+
+// resourceIndexStaticSettingsDiff recreates the index when the static settings are changed on an open index,
+// unless `close_to_update_static_settings` is set. The plan fails for the analysis settings changes instead.
+func resourceIndexStaticSettingsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.Get("state").(string) == "closed" || d.Get("close_to_update_static_settings").(bool) {
+		return nil
+	}
+	for _, key := range closedIndexSettingsKeys {
+		fieldKey := utils.ConvertSettingsKeyToTFFieldKey(key)
+		if !d.HasChange(fieldKey) {
+			continue
+		}
+		tflog.Info(ctx, fmt.Sprintf("static setting %s change requires recreating the index", key))
+		if err := d.ForceNew(fieldKey); err != nil {
+			return err
+		}
+	}
+	var changes []string
+	for _, fieldKey := range sortedKeys(analysisSettingsKeys) {
+		if d.HasChange(fieldKey) {
+			changes = append(changes, fieldKey)
+		}
+	}
+	if len(changes) > 0 {
+		return fmt.Errorf("the analysis settings [%s] can only be updated on a closed index. Set close_to_update_static_settings = true to close the index during the update, or state = \"closed\"", strings.Join(changes, ", "))
+	}
+	return nil
+}
+
 func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, diags := clients.NewApiClient(d, meta)
 	if diags.HasError() {
@@ -670,6 +744,31 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 	if diags.HasError() {
 		return diags
 	}
+
+	index, diags := expandIndex(ctx, d, indexName)
+	if diags.HasError() {
+		return diags
+	}
+	params, diags := expandPutIndexParams(ctx, d, client)
+	if diags.HasError() {
+		return diags
+	}
+
+	if diags := elasticsearch.PutIndex(ctx, client, index, params); diags.HasError() {
+		return diags
+	}
+	d.SetId(id.String())
+
+	if d.Get("state").(string) == "closed" {
+		if diags := elasticsearch.CloseIndex(ctx, client, indexName); diags.HasError() {
+			return diags
+		}
+	}
+	return resourceIndexRead(ctx, d, meta)
+}
+
+// expandIndex returns the index definition, including its aliases, mappings and settings.
+func expandIndex(ctx context.Context, d *schema.ResourceData, indexName string) (*models.Index, diag.Diagnostics) {
 	var index models.Index
 	index.Name = indexName
 
@@ -677,7 +776,7 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		aliases := v.(*schema.Set)
 		als, diags := ExpandIndexAliases(aliases)
 		if diags.HasError() {
-			return diags
+			return nil, diags
 		}
 		index.Aliases = als
 	}
@@ -686,7 +785,7 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		maps := make(map[string]interface{})
 		if v.(string) != "" {
 			if err := json.Unmarshal([]byte(v.(string)), &maps); err != nil {
-				return diag.FromErr(err)
+				return nil, diag.FromErr(err)
 			}
 		}
 		index.Mappings = maps
@@ -698,49 +797,14 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 
 	analysis := map[string]interface{}{}
-	if analyzerJSON, ok := d.GetOk("analysis_analyzer"); ok {
-		var analyzer map[string]interface{}
-		bytes := []byte(analyzerJSON.(string))
-		err := json.Unmarshal(bytes, &analyzer)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		analysis["analyzer"] = analyzer
-	}
-	if tokenizerJSON, ok := d.GetOk("analysis_tokenizer"); ok {
-		var tokenizer map[string]interface{}
-		bytes := []byte(tokenizerJSON.(string))
-		err := json.Unmarshal(bytes, &tokenizer)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		analysis["tokenizer"] = tokenizer
-	}
-	if charFilterJSON, ok := d.GetOk("analysis_char_filter"); ok {
-		var filter map[string]interface{}
-		bytes := []byte(charFilterJSON.(string))
-		if err := json.Unmarshal(bytes, &filter); err != nil {
-			return diag.FromErr(err)
-		}
-		analysis["char_filter"] = filter
-	}
-	if filterJSON, ok := d.GetOk("analysis_filter"); ok {
-		var filter map[string]interface{}
-		bytes := []byte(filterJSON.(string))
-		err := json.Unmarshal(bytes, &filter)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		analysis["filter"] = filter
-	}
-	if normalizerJSON, ok := d.GetOk("analysis_normalizer"); ok {
-		var normalizer map[string]interface{}
-		bytes := []byte(normalizerJSON.(string))
-		err := json.Unmarshal(bytes, &normalizer)
-		if err != nil {
-			return diag.FromErr(err)
+	for fieldKey, key := range analysisSettingsKeys {
+		if v, ok := d.GetOk(fieldKey); ok {
+			var definitions map[string]interface{}
+			if err := json.Unmarshal([]byte(v.(string)), &definitions); err != nil {
+				return nil, diag.FromErr(err)
+			}
+			analysis[key] = definitions
 		}
-		analysis["normalizer"] = normalizer
 	}
 	if len(analysis) > 0 {
 		index.Settings["analysis"] = analysis
@@ -753,44 +817,43 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 			setting := s.(map[string]interface{})
 			name := setting["name"].(string)
 			if _, ok := index.Settings[name]; ok {
-				return diag.FromErr(fmt.Errorf("setting '%s' is already defined by the other field, please remove it from `settings` to avoid unexpected settings", name))
+				return nil, diag.FromErr(fmt.Errorf("setting '%s' is already defined by the other field, please remove it from `settings` to avoid unexpected settings", name))
 			}
 			index.Settings[name] = setting["value"]
 		}
 	}
 
+	return &index, nil
+}
+
+func expandPutIndexParams(ctx context.Context, d *schema.ResourceData, client *clients.ApiClient) (*models.PutIndexParams, diag.Diagnostics) {
 	params := models.PutIndexParams{
 		WaitForActiveShards: d.Get("wait_for_active_shards").(string),
 		IncludeTypeName:     d.Get("include_type_name").(bool),
 	}
 	serverVersion, diags := client.ServerVersion(ctx)
 	if diags.HasError() {
-		return diags
+		return nil, diags
 	}
 	if includeTypeName := d.Get("include_type_name").(bool); includeTypeName {
 		if serverVersion.GreaterThanOrEqual(includeTypeNameMinUnsupportedVersion) {
-			return diag.FromErr(fmt.Errorf("'include_type_name' field is supported only for elasticsearch v7.x"))
+			return nil, diag.FromErr(fmt.Errorf("'include_type_name' field is supported only for elasticsearch v7.x"))
 		}
 		params.IncludeTypeName = includeTypeName
 	}
 	masterTimeout, err := time.ParseDuration(d.Get("master_timeout").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return nil, diag.FromErr(err)
 	}
 	params.MasterTimeout = masterTimeout
 
 	timeout, err := time.ParseDuration(d.Get("timeout").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return nil, diag.FromErr(err)
 	}
 	params.Timeout = timeout
 
-	if diags := elasticsearch.PutIndex(ctx, client, &index, &params); diags.HasError() {
-		return diags
-	}
-
-	d.SetId(id.String())
-	return resourceIndexRead(ctx, d, meta)
+	return &params, nil
 }
 
 // Because of limitation of ES API we must handle changes to aliases, mappings and settings separately
@@ -799,10 +862,56 @@ func resourceIndexUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 	if diags.HasError() {
 		return diags
 	}
-	indexName := d.Get("name").(string)
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	// the concrete name is unknown in the plan of a migration
+	oldConcreteName, _ := d.GetChange("concrete_name")
+	indexName := oldConcreteName.(string)
+	if indexName == "" {
+		indexName = compId.ResourceId
+	}
+
+	oldState, newState := d.GetChange("state")
+	closed := oldState.(string) == "closed"
+	staticSettings, diags := expandStaticSettingsChanges(d)
+	if diags.HasError() {
+		return diags
+	}
+	// the static settings of a closed index are updated before opening it
+	if closed && len(staticSettings) > 0 {
+		if diags := elasticsearch.UpdateIndexSettings(ctx, client, indexName, staticSettings); diags.HasError() {
+			return diags
+		}
+		staticSettings = nil
+	}
+	if closed && newState.(string) == "open" {
+		if diags := openIndex(ctx, d, client, indexName); diags.HasError() {
+			return diags
+		}
+		closed = false
+	}
+
+	// the index migrated to a new index is created open with the updated aliases, settings and mappings,
+	// only its state remains to be updated
+	migrated := false
+	if d.HasChange("mappings") && len(d.Get("mapping_migration").([]interface{})) > 0 {
+		oldMappings, newMappings := d.GetChange("mappings")
+		if len(mappingsChangesRequiringNewIndex(ctx, oldMappings.(string), newMappings.(string))) > 0 {
+			newIndexName, diags := migrateIndex(ctx, d, client, indexName)
+			if diags.HasError() {
+				return diags
+			}
+			indexName, migrated, closed, staticSettings = newIndexName, true, false, nil
+			if err := d.Set("concrete_name", indexName); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
 
 	// aliases
-	if d.HasChange("alias") {
+	if !migrated && d.HasChange("alias") {
 		oldAliases, newAliases := d.GetChange("alias")
 		eold, diags := ExpandIndexAliases(oldAliases.(*schema.Set))
 		if diags.HasError() {
@@ -860,14 +969,17 @@ func resourceIndexUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 			}
 		}
 		for k, v := range ns {
-			if _, ok := updatedSettings[k]; ok && v != nil {
-				return diag.FromErr(fmt.Errorf("setting '%s' is already updated by the other field, please remove it from `settings` to avoid unexpected settings", k))
-			} else {
-				updatedSettings[k] = v
+			if _, ok := updatedSettings[k]; ok {
+				if v != nil {
+					return diag.FromErr(fmt.Errorf("setting '%s' is already updated by the other field, please remove it from `settings` to avoid unexpected settings", k))
+				}
+				// the setting moved from `settings` to its dedicated field, keep the value of the field
+				continue
 			}
+			updatedSettings[k] = v
 		}
 	}
-	if len(updatedSettings) > 0 {
+	if !migrated && len(updatedSettings) > 0 {
 		tflog.Trace(ctx, fmt.Sprintf("settings to update: %+v", updatedSettings))
 		if diags := elasticsearch.UpdateIndexSettings(ctx, client, indexName, updatedSettings); diags.HasError() {
 			return diags
@@ -875,17 +987,183 @@ func resourceIndexUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 
 	// mappings
-	if d.HasChange("mappings") {
+	if !migrated && d.HasChange("mappings") {
 		// at this point we know there are mappings defined and there is a change which we can apply
-		mappings := d.Get("mappings").(string)
+		oldMappings, newMappings := d.GetChange("mappings")
+		mappings, err := mappingsUpdateBody(oldMappings.(string), newMappings.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 		if diags := elasticsearch.UpdateIndexMappings(ctx, client, indexName, mappings); diags.HasError() {
 			return diags
 		}
 	}
 
+	// the open index is closed for the static settings update, and reopened unless it should remain closed
+	if len(staticSettings) > 0 {
+		tflog.Info(ctx, fmt.Sprintf(`Closing index "%s" to update its static settings`, indexName))
+		if diags := elasticsearch.CloseIndex(ctx, client, indexName); diags.HasError() {
+			return diags
+		}
+		closed = true
+		if diags := elasticsearch.UpdateIndexSettings(ctx, client, indexName, staticSettings); diags.HasError() {
+			if newState.(string) == "open" {
+				return append(diags, openIndex(ctx, d, client, indexName)...)
+			}
+			return diags
+		}
+	}
+	if closed && newState.(string) == "open" {
+		if diags := openIndex(ctx, d, client, indexName); diags.HasError() {
+			return diags
+		}
+	}
+	if !closed && newState.(string) == "closed" {
+		if diags := elasticsearch.CloseIndex(ctx, client, indexName); diags.HasError() {
+			return diags
+		}
+	}
+
 	return resourceIndexRead(ctx, d, meta)
 }
 
+// openIndex opens the index, waiting for `wait_for_active_shards` to be started.
+func openIndex(ctx context.Context, d *schema.ResourceData, client *clients.ApiClient, indexName string) diag.Diagnostics {
+	timeout, err := time.ParseDuration(d.Get("timeout").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return elasticsearch.OpenIndex(ctx, client, indexName, d.Get("wait_for_active_shards").(string), timeout)
+}
+
+// expandStaticSettingsChanges returns the changed static settings which can be updated on a closed index. The
+// settings removed from the configuration are reset, as are the analysis definitions before being redefined.
+func expandStaticSettingsChanges(d *schema.ResourceData) (map[string]interface{}, diag.Diagnostics) {
+	settings := map[string]interface{}{}
+	for _, key := range closedIndexSettingsKeys {
+		fieldKey := utils.ConvertSettingsKeyToTFFieldKey(key)
+		if !d.HasChange(fieldKey) {
+			continue
+		}
+		settings[key] = nil
+		if isSettingManaged(d, fieldKey) {
+			settings[key] = d.Get(fieldKey)
+		}
+	}
+	for fieldKey, key := range analysisSettingsKeys {
+		if !d.HasChange(fieldKey) {
+			continue
+		}
+		oldJSON, newJSON := d.GetChange(fieldKey)
+		for i, v := range []interface{}{oldJSON, newJSON} {
+			definitions := map[string]interface{}{}
+			if v.(string) != "" {
+				if err := json.Unmarshal([]byte(v.(string)), &definitions); err != nil {
+					return nil, diag.FromErr(err)
+				}
+			}
+			for name, definition := range definitions {
+				prefix := fmt.Sprintf("analysis.%s.%s", key, name)
+				if i == 0 {
+					settings[prefix+".*"] = nil
+				} else {
+					settings[prefix] = definition
+				}
+			}
+		}
+	}
+	return settings, nil
+}
+
+// migrateIndex creates a new index with the updated definition, blocks the writes to the current index, copies
+// its documents, then atomically moves the aliases to the new index and replaces the current index by an alias.
+// It returns the name of the new index.
+func migrateIndex(ctx context.Context, d *schema.ResourceData, client *clients.ApiClient, indexName string) (string, diag.Diagnostics) {
+	migration := d.Get("mapping_migration").([]interface{})[0].(map[string]interface{})
+	timeout, err := time.ParseDuration(migration["timeout"].(string))
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+
+	newIndexName, err := nextIndexName(d.Get("name").(string), indexName)
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+	// the aliases are added once the documents are copied
+	index, diags := expandIndex(ctx, d, newIndexName)
+	if diags.HasError() {
+		return "", diags
+	}
+	aliases := index.Aliases
+	index.Aliases = nil
+	params, diags := expandPutIndexParams(ctx, d, client)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	tflog.Info(ctx, fmt.Sprintf(`Migrating index "%s" to "%s"`, indexName, newIndexName))
+	if diags := elasticsearch.PutIndex(ctx, client, index, params); diags.HasError() {
+		return "", diags
+	}
+
+	// the writes to the current index are blocked until it's deleted, so that no document is left behind,
+	// and unblocked when the migration is rolled back
+	blocked, _ := d.GetChange("blocks_write")
+	rollback := func(diags diag.Diagnostics) diag.Diagnostics {
+		if !blocked.(bool) {
+			diags = append(diags, elasticsearch.UpdateIndexSettings(ctx, client, indexName, map[string]interface{}{"index.blocks.write": nil})...)
+		}
+		return append(diags, elasticsearch.DeleteIndex(ctx, client, newIndexName)...)
+	}
+	if !blocked.(bool) {
+		if diags := elasticsearch.UpdateIndexSettings(ctx, client, indexName, map[string]interface{}{"index.blocks.write": true}); diags.HasError() {
+			return "", append(diags, elasticsearch.DeleteIndex(ctx, client, newIndexName)...)
+		}
+	}
+
+	taskId, diags := elasticsearch.Reindex(ctx, client, indexName, newIndexName, migration["pipeline"].(string))
+	if diags.HasError() {
+		return "", rollback(diags)
+	}
+	if diags := elasticsearch.WaitForReindexTask(ctx, client, taskId, timeout); diags.HasError() {
+		// the reindex task still running on timeout is cancelled first, its writes would recreate the deleted index
+		return "", rollback(append(diags, elasticsearch.CancelTask(ctx, client, taskId)...))
+	}
+
+	// the current index is deleted in the same call as the aliases are moved, and replaced by an alias named
+	// after `name`, so that the writes to `name` don't create a new empty index
+	actions := []models.AliasAction{{Type: "remove_index", Index: indexName}}
+	for name, alias := range aliases {
+		alias.Name = name
+		actions = append(actions, models.AliasAction{Type: "add", Index: newIndexName, Alias: alias})
+	}
+	actions = append(actions, models.AliasAction{Type: "add", Index: newIndexName, Alias: models.IndexAlias{Name: d.Get("name").(string)}})
+	if diags := elasticsearch.UpdateAliases(ctx, client, actions); diags.HasError() {
+		return "", rollback(diags)
+	}
+
+	return newIndexName, nil
+}
+
+var indexNameVersionRegex = regexp.MustCompile(`^(.+)-(\d{6})$`)
+
+// nextIndexName returns the name of the index to migrate the concrete index to, e.g. `my-index-000002` for
+// both `my-index` and `my-index-000001`.
+func nextIndexName(name, concreteName string) (string, error) {
+	if concreteName == name {
+		return fmt.Sprintf("%s-%06d", name, 2), nil
+	}
+	matches := indexNameVersionRegex.FindStringSubmatch(concreteName)
+	if matches == nil || matches[1] != name {
+		return "", fmt.Errorf(`unable to migrate the index "%s", its name doesn't match "%s-<version>"`, concreteName, name)
+	}
+	version, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%06d", name, version+1), nil
+}
+
 func flattenIndexSettings(settings []interface{}) map[string]interface{} {
 	ns := make(map[string]interface{})
 	if len(settings) > 0 {
@@ -898,6 +1176,23 @@ func flattenIndexSettings(settings []interface{}) map[string]interface{} {
 	return ns
 }
 
+// resolveConcreteIndexName returns the name of the concrete index, the name being the one of the alias replacing
+// the index once migrated by `mapping_migration`. It returns an empty name when the index doesn't exist.
+func resolveConcreteIndexName(ctx context.Context, client *clients.ApiClient, name string) (string, diag.Diagnostics) {
+	indices, diags := elasticsearch.GetIndices(ctx, client, name, "all")
+	if diags.HasError() {
+		return "", diags
+	}
+	names := sortedKeys(indices)
+	switch len(names) {
+	case 0:
+		return "", nil
+	case 1:
+		return names[0], nil
+	}
+	return "", diag.Errorf(`"%s" is an alias of several indices [%s], only a single index can be managed`, name, strings.Join(names, ", "))
+}
+
 func resourceIndexRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, diags := clients.NewApiClient(d, meta)
 	if diags.HasError() {
@@ -907,24 +1202,45 @@ func resourceIndexRead(ctx context.Context, d *schema.ResourceData, meta interfa
 	if diags.HasError() {
 		return diags
 	}
-	indexName := compId.ResourceId
+	name := compId.ResourceId
 
-	if err := d.Set("name", indexName); err != nil {
-		return diag.FromErr(err)
+	// the name is only missing on import
+	if d.Get("name").(string) == "" {
+		if err := d.Set("name", name); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	// the concrete index differs from the name once migrated, it's only missing on creation and import
+	indexName := d.Get("concrete_name").(string)
+	if indexName == "" {
+		indexName, diags = resolveConcreteIndexName(ctx, client, name)
+		if diags.HasError() {
+			return diags
+		}
 	}
 
-	index, diags := elasticsearch.GetIndex(ctx, client, indexName)
-	if index == nil && diags == nil {
+	var index *models.Index
+	if indexName != "" {
+		index, diags = elasticsearch.GetIndex(ctx, client, indexName)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	if index == nil {
 		// no index found on ES side
-		tflog.Warn(ctx, fmt.Sprintf(`Index "%s" not found, removing from state`, compId.ResourceId))
+		tflog.Warn(ctx, fmt.Sprintf(`Index "%s" not found, removing from state`, name))
 		d.SetId("")
-		return diags
+		return nil
 	}
-	if diags.HasError() {
-		return diags
+	if err := d.Set("concrete_name", indexName); err != nil {
+		return diag.FromErr(err)
 	}
 
 	if index.Aliases != nil {
+		// the alias named after `name` replaces the migrated index, it isn't managed by the `alias` blocks
+		if name != indexName {
+			delete(index.Aliases, name)
+		}
 		aliases, diags := FlattenIndexAliases(index.Aliases)
 		if diags.HasError() {
 			return diags
@@ -942,8 +1258,17 @@ func resourceIndexRead(ctx context.Context, d *schema.ResourceData, meta interfa
 			return diag.FromErr(err)
 		}
 	}
-	// TODO: We ideally should set read settings to each field to detect changes
-	// But for now, setting it will cause unexpected diff for the existing clients which use `settings`
+	stats, diags := elasticsearch.GetIndicesStats(ctx, client, indexName, "")
+	if diags.HasError() {
+		return diags
+	}
+	state := "open"
+	if stats[indexName].Status == "close" {
+		state = "closed"
+	}
+	if err := d.Set("state", state); err != nil {
+		return diag.FromErr(err)
+	}
 	if index.Settings != nil {
 		s, err := json.Marshal(index.Settings)
 		if err != nil {
@@ -953,9 +1278,88 @@ func resourceIndexRead(ctx context.Context, d *schema.ResourceData, meta interfa
 			return diag.FromErr(err)
 		}
 	}
+	// only the individually defined settings are read back, the settings defined in the deprecated `settings`
+	// block or left to their default are not tracked to avoid unexpected diffs
+	for key, typ := range allSettingsKeys {
+		fieldKey := utils.ConvertSettingsKeyToTFFieldKey(key)
+		if !isSettingManaged(d, fieldKey) {
+			continue
+		}
+		var value interface{}
+		if v, ok := lookupIndexSetting(index.Settings, key); ok {
+			v, err := normalizeIndexSetting(key, typ, v)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			value = v
+		}
+		if err := d.Set(fieldKey, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	return diags
 }
 
+// isSettingManaged checks whether the setting is defined in the configuration or, when the configuration
+// isn't available as on refresh, in the state.
+func isSettingManaged(d *schema.ResourceData, fieldKey string) bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() {
+		raw = d.GetRawState()
+	}
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsObjectType() || !raw.Type().HasAttribute(fieldKey) {
+		return false
+	}
+	v := raw.GetAttr(fieldKey)
+	if v.IsNull() || !v.IsKnown() {
+		return false
+	}
+	if v.Type().IsCollectionType() {
+		return v.LengthInt() > 0
+	}
+	return true
+}
+
+// lookupIndexSetting returns the value of the flat setting, with or without the `index.` prefix.
+func lookupIndexSetting(settings map[string]interface{}, key string) (interface{}, bool) {
+	if v, ok := settings[key]; ok {
+		return v, true
+	}
+	v, ok := settings["index."+key]
+	return v, ok
+}
+
+// normalizeIndexSetting converts the value returned by Elasticsearch, where numbers and booleans are strings,
+// to the type of the setting field.
+func normalizeIndexSetting(key string, typ schema.ValueType, value interface{}) (interface{}, error) {
+	switch typ {
+	case schema.TypeInt:
+		switch v := value.(type) {
+		case string:
+			i, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert setting '%s' value %v to int: %w", key, value, err)
+			}
+			return i, nil
+		case float64:
+			return int(v), nil
+		}
+	case schema.TypeBool:
+		if v, ok := value.(string); ok {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert setting '%s' value %v to bool: %w", key, value, err)
+			}
+			return b, nil
+		}
+	case schema.TypeSet:
+		if v, ok := value.(string); ok {
+			return []interface{}{v}, nil
+		}
+	}
+	return value, nil
+}
+
 func resourceIndexDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	if d.Get("deletion_protection").(bool) {
 		return diag.Errorf("cannot destroy index without setting deletion_protection=false and running `terraform apply`")
@@ -964,49 +1368,21 @@ func resourceIndexDelete(ctx context.Context, d *schema.ResourceData, meta inter
 	if diags.HasError() {
 		return diags
 	}
-	id := d.Id()
-	compId, diags := clients.CompositeIdFromStr(id)
+	compId, diags := clients.CompositeIdFromStr(d.Id())
 	if diags.HasError() {
 		return diags
 	}
-	if diags := elasticsearch.DeleteIndex(ctx, client, compId.ResourceId); diags.HasError() {
+	indexName := d.Get("concrete_name").(string)
+	if indexName == "" {
+		indexName = compId.ResourceId
+	}
+	if diags := elasticsearch.DeleteIndex(ctx, client, indexName); diags.HasError() {
 		return diags
 	}
 	return diags
 }
 
+// IsMappingForceNewRequired checks whether the changes of the properties can't be applied to the existing index.
 func IsMappingForceNewRequired(ctx context.Context, old map[string]interface{}, new map[string]interface{}) bool {
-	for k, v := range old {
-		oldFieldSettings := v.(map[string]interface{})
-		newFieldSettings, ok := new[k]
-		// When field is removed, it'll be ignored in elasticsearch
-		if !ok {
-			tflog.Warn(ctx, fmt.Sprintf("removing %s field in mappings is ignored. Re-index to remove the field completely.", k))
-			continue
-		}
-		newSettings := newFieldSettings.(map[string]interface{})
-		// check if the "type" field exists and match with new one
-		if s, ok := oldFieldSettings["type"]; ok {
-			if ns, ok := newSettings["type"]; ok {
-				if !reflect.DeepEqual(s, ns) {
-					return true
-				}
-				continue
-			} else {
-				return true
-			}
-		}
-
-		// if we have "mapping" field, let's call ourself to check again
-		if s, ok := oldFieldSettings["properties"]; ok {
-			if ns, ok := newSettings["properties"]; ok {
-				if IsMappingForceNewRequired(context.Background(), s.(map[string]interface{}), ns.(map[string]interface{})) {
-					return true
-				}
-			} else {
-				tflog.Warn(ctx, fmt.Sprintf("removing %s propeties in mappings is ignored, if you neeed to remove it completely, please recreate the index", k))
-			}
-		}
-	}
-	return false
+	return len(propertiesChangesRequiringNewIndex(ctx, "properties", old, new)) > 0
 }
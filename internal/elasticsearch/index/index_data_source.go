@@ -0,0 +1,225 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceIndex() *schema.Resource {
+	indexSchema := indexDataSourceAttributes()
+	indexSchema["id"] = &schema.Schema{
+		Description: "Internal identifier of the resource",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+	indexSchema["name"] = &schema.Schema{
+		Description: "Name of the index.",
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+
+	utils.AddConnectionSchema(indexSchema)
+
+	return &schema.Resource{
+		Description: "Gets information about an index, which doesn't need to be managed by Terraform. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-get-index.html",
+
+		ReadContext: dataSourceIndexRead,
+
+		Schema: indexSchema,
+	}
+}
+
+// indexDataSourceAttributes returns the computed attributes describing an index, shared by the index and
+// indices data sources.
+func indexDataSourceAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Description: "Name of the index.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"uuid": {
+			Description: "UUID of the index.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"health": {
+			Description: "Health of the index: `green`, `yellow` or `red`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"status": {
+			Description: "Status of the index: `open` or `close`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"docs_count": {
+			Description: "Number of documents in the index, not including the nested documents. Zero for closed indices.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"store_size_in_bytes": {
+			Description: "Total size of the primary and replica shards of the index, in bytes. Zero for closed indices.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"creation_date": {
+			Description: "Creation date of the index, in RFC3339 format.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"alias": {
+			Description: "Aliases of the index.",
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "Index alias name.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"filter": {
+						Description: "Query used to limit documents the alias can access.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"index_routing": {
+						Description: "Value used to route indexing operations to a specific shard.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"is_hidden": {
+						Description: "If true, the alias is hidden.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"is_write_index": {
+						Description: "If true, the index is the write index for the alias.",
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					"routing": {
+						Description: "Value used to route indexing and search operations to a specific shard.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"search_routing": {
+						Description: "Value used to route search operations to a specific shard.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"mappings": {
+			Description: "Mappings of the index, as JSON.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"settings_raw": {
+			Description: "All the settings of the index, as flat JSON.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+func dataSourceIndexRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	indexName := d.Get("name").(string)
+	id, diags := client.ID(ctx, indexName)
+	if diags.HasError() {
+		return diags
+	}
+
+	indices, diags := elasticsearch.GetIndices(ctx, client, indexName, "")
+	if diags.HasError() {
+		return diags
+	}
+	index, ok := indices[indexName]
+	if !ok {
+		return diag.Errorf(`Index "%s" not found`, indexName)
+	}
+	stats, diags := elasticsearch.GetIndicesStats(ctx, client, indexName, "")
+	if diags.HasError() {
+		return diags
+	}
+
+	attributes, diags := flattenIndexDataSource(index, stats[indexName])
+	if diags.HasError() {
+		return diags
+	}
+	for key, value := range attributes {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(id.String())
+	return diags
+}
+
+// flattenIndexDataSource returns the attributes of indexDataSourceAttributes describing the index.
+func flattenIndexDataSource(index models.Index, stats models.IndexStats) (map[string]interface{}, diag.Diagnostics) {
+	aliases, diags := FlattenIndexAliases(index.Aliases)
+	if diags.HasError() {
+		return nil, diags
+	}
+	mappings, err := json.Marshal(index.Mappings)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	settings, err := json.Marshal(index.Settings)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	attributes := map[string]interface{}{
+		"name":                index.Name,
+		"uuid":                stats.UUID,
+		"health":              stats.Health,
+		"status":              stats.Status,
+		"docs_count":          0,
+		"store_size_in_bytes": 0,
+		"creation_date":       "",
+		"alias":               aliases,
+		"mappings":            string(mappings),
+		"settings_raw":        string(settings),
+	}
+	// the statistics are missing on closed indices
+	if stats.DocsCount != "" {
+		count, err := strconv.Atoi(stats.DocsCount)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		attributes["docs_count"] = count
+	}
+	if stats.StoreSize != "" {
+		size, err := strconv.Atoi(stats.StoreSize)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		attributes["store_size_in_bytes"] = size
+	}
+	if stats.CreationDate != "" {
+		millis, err := strconv.ParseInt(stats.CreationDate, 10, 64)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		attributes["creation_date"] = time.UnixMilli(millis).UTC().Format(time.RFC3339)
+	}
+	return attributes, diags
+}
@@ -0,0 +1,82 @@
+package index_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceIndex(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceIndex(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index.test", "name", indexName),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_index.test", "uuid"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index.test", "status", "open"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index.test", "health", "green"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index.test", "docs_count", "0"),
+					resource.TestMatchResourceAttr("data.elasticstack_elasticsearch_index.test", "creation_date", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T`)),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index.test", "alias.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index.test", "alias.0.name", "test_alias"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index.test", "mappings", `{"properties":{"field1":{"type":"text"}}}`),
+					resource.TestMatchResourceAttr("data.elasticstack_elasticsearch_index.test", "settings_raw", regexp.MustCompile(`"index.number_of_replicas":"0"`)),
+				),
+			},
+			{
+				Config:      testAccDataSourceIndexMissing(indexName),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`Index "%s-missing" not found`, indexName)),
+			},
+		},
+	})
+}
+
+func testAccDataSourceIndex(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name               = "%s"
+  number_of_replicas = 0
+
+  alias {
+    name = "test_alias"
+  }
+
+  mappings = jsonencode({
+    properties = {
+      field1 = { type = "text" }
+    }
+  })
+
+  deletion_protection = false
+}
+
+data "elasticstack_elasticsearch_index" "test" {
+  name = elasticstack_elasticsearch_index.test.name
+}
+	`, name)
+}
+
+func testAccDataSourceIndexMissing(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_index" "test" {
+  name = "%s-missing"
+}
+	`, name)
+}
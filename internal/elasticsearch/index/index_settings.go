@@ -0,0 +1,256 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceIndexSettings() *schema.Resource {
+	settingsSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"index": {
+			Description: "Comma separated list of index names, aliases, data streams and wildcard patterns, e.g. `logs-*`, of the indices to configure. The indices aren't created nor deleted by this resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"expand_wildcards": {
+			Description:  "Comma separated list of the states of the indices the wildcard patterns can match: `all`, `open`, `closed`, `hidden` or `none`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "open",
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(all|open|closed|hidden|none)(,(all|open|closed|hidden|none))*$`), "must be a comma separated list of all, open, closed, hidden or none"),
+		},
+		"setting": {
+			Description: "Dynamic index setting to set and track on all the matching indices.",
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "The name of the setting, e.g. `index.number_of_replicas`.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"value": {
+						Description: "The value of the setting to set and track.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"value_list": {
+						Description: "The list of values to be set for the key, where the list is required.",
+						Type:        schema.TypeList,
+						Optional:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+		"reset_on_destroy": {
+			Description: "If true, the settings are reset to their default value on the matching indices when the resource is destroyed, otherwise they are left as is.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"indices": {
+			Description: "Names of the indices matching `index`, sorted by name.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(settingsSchema)
+
+	return &schema.Resource{
+		Description: "Manages the dynamic settings of existing indices matching a name or a wildcard pattern, like the indices created by Beats, Logstash or an index lifecycle rollover. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-update-settings.html",
+
+		CreateContext: resourceIndexSettingsPut,
+		UpdateContext: resourceIndexSettingsPut,
+		ReadContext:   resourceIndexSettingsRead,
+		DeleteContext: resourceIndexSettingsDelete,
+
+		Schema: settingsSchema,
+	}
+}
+
+func resourceIndexSettingsPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	pattern := d.Get("index").(string)
+	id, diags := client.ID(ctx, pattern)
+	if diags.HasError() {
+		return diags
+	}
+
+	settings, diags := expandIndexSettingBlocks(d.Get("setting").(*schema.Set))
+	if diags.HasError() {
+		return diags
+	}
+	// the settings which aren't managed anymore are reset to their default value
+	oldSettings, _ := d.GetChange("setting")
+	for _, s := range oldSettings.(*schema.Set).List() {
+		name := s.(map[string]interface{})["name"].(string)
+		if _, ok := settings[name]; !ok {
+			settings[name] = nil
+		}
+	}
+
+	if diags := elasticsearch.UpdateIndicesSettings(ctx, client, pattern, d.Get("expand_wildcards").(string), settings); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceIndexSettingsRead(ctx, d, meta)
+}
+
+func resourceIndexSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	pattern := compId.ResourceId
+
+	indices, diags := elasticsearch.GetIndices(ctx, client, pattern, d.Get("expand_wildcards").(string))
+	if indices == nil && diags == nil {
+		tflog.Warn(ctx, fmt.Sprintf(`Index "%s" not found, removing from state`, pattern))
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	settings, diags := expandIndexSettingBlocks(d.Get("setting").(*schema.Set))
+	if diags.HasError() {
+		return diags
+	}
+	if err := d.Set("index", pattern); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("setting", flattenIndexSettingBlocks(settings, indices)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("indices", sortedKeys(indices)); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceIndexSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.Get("reset_on_destroy").(bool) {
+		tflog.Debug(ctx, fmt.Sprintf(`Leaving the settings of "%s" as is`, d.Get("index").(string)))
+		return nil
+	}
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	settings, diags := expandIndexSettingBlocks(d.Get("setting").(*schema.Set))
+	if diags.HasError() {
+		return diags
+	}
+	for name := range settings {
+		settings[name] = nil
+	}
+	if diags := elasticsearch.UpdateIndicesSettings(ctx, client, compId.ResourceId, d.Get("expand_wildcards").(string), settings); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+// expandIndexSettingBlocks returns the settings of the `setting` blocks keyed by name.
+func expandIndexSettingBlocks(definedSettings *schema.Set) (map[string]interface{}, diag.Diagnostics) {
+	settings := make(map[string]interface{}, definedSettings.Len())
+	for _, s := range definedSettings.List() {
+		setting := s.(map[string]interface{})
+		name := setting["name"].(string)
+		if _, ok := settings[name]; ok {
+			return nil, diag.Errorf(`The setting "%s" is defined more than once`, name)
+		}
+
+		value, valueList := setting["value"].(string), setting["value_list"].([]interface{})
+		switch {
+		case value != "" && len(valueList) > 0:
+			return nil, diag.Errorf(`Only one of "value" or "value_list" can be set for the setting "%s"`, name)
+		case value != "":
+			settings[name] = value
+		case len(valueList) > 0:
+			settings[name] = valueList
+		default:
+			return nil, diag.Errorf(`One of "value" or "value_list" must be set to a non empty value for the setting "%s"`, name)
+		}
+	}
+	return settings, nil
+}
+
+// flattenIndexSettingBlocks returns the `setting` blocks of the managed settings as they are defined on the indices.
+// A setting missing from one of the indices, or differing between them, is left out so the drift is reported.
+func flattenIndexSettingBlocks(settings map[string]interface{}, indices map[string]models.Index) []interface{} {
+	result := make([]interface{}, 0, len(settings))
+	for _, name := range sortedKeys(settings) {
+		value := settings[name]
+		consistent := true
+		for i, index := range sortedKeys(indices) {
+			v, ok := lookupIndexSetting(indices[index].Settings, name)
+			if !ok || (i > 0 && !indexSettingEqual(value, v)) {
+				consistent = false
+				break
+			}
+			value = v
+		}
+		if !consistent {
+			continue
+		}
+
+		s := map[string]interface{}{"name": name}
+		switch v := value.(type) {
+		case []interface{}:
+			s["value_list"] = v
+		default:
+			s["value"] = fmt.Sprintf("%v", v)
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+// indexSettingEqual compares the values of a setting, the numbers and booleans being strings in the responses.
+func indexSettingEqual(a, b interface{}) bool {
+	if list, ok := a.([]interface{}); ok {
+		otherList, ok := b.([]interface{})
+		return ok && reflect.DeepEqual(list, otherList)
+	}
+	return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b)
+}
@@ -0,0 +1,142 @@
+package index_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceIndexSettingsPattern(t *testing.T) {
+	prefix := sdkacctest.RandStringFromCharSet(16, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexSettingsPattern(prefix, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_settings.test", "index", prefix+"-*"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_settings.test", "indices.#", "2"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_settings.test", "setting.#", "2"),
+					checkIndexSetting(prefix+"-000001", "index.number_of_replicas", "0"),
+					checkIndexSetting(prefix+"-000002", "index.refresh_interval", "10s"),
+				),
+			},
+			{
+				PreConfig: func() {
+					updateIndexSettings(t, prefix+"-000002", `{"index.number_of_replicas": 1}`)
+				},
+				Config:             testAccResourceIndexSettingsPattern(prefix, true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// the settings which aren't managed anymore are reset
+				Config: testAccResourceIndexSettingsPattern(prefix, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_settings.test", "setting.#", "1"),
+					checkIndexSetting(prefix+"-000002", "index.number_of_replicas", "0"),
+					checkIndexSetting(prefix+"-000001", "index.refresh_interval", ""),
+				),
+			},
+			{
+				// the managed settings are reset when the resource is destroyed
+				Config: testAccResourceIndexSettingsPatternIndices(prefix),
+				Check: resource.ComposeTestCheckFunc(
+					checkIndexSetting(prefix+"-000001", "index.number_of_replicas", "1"),
+					checkIndexSetting(prefix+"-000002", "index.number_of_replicas", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexSettingsPatternIndices(prefix string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "first" {
+  name                = "%[1]s-000001"
+  deletion_protection = false
+}
+
+resource "elasticstack_elasticsearch_index" "second" {
+  name                = "%[1]s-000002"
+  deletion_protection = false
+}
+	`, prefix)
+}
+
+func testAccResourceIndexSettingsPattern(prefix string, refreshInterval bool) string {
+	settings := `
+  setting {
+    name  = "index.number_of_replicas"
+    value = "0"
+  }`
+	if refreshInterval {
+		settings += `
+  setting {
+    name  = "index.refresh_interval"
+    value = "10s"
+  }`
+	}
+	return testAccResourceIndexSettingsPatternIndices(prefix) + fmt.Sprintf(`
+resource "elasticstack_elasticsearch_index_settings" "test" {
+  index = "%s-*"
+%s
+
+  reset_on_destroy = true
+
+  depends_on = [
+    elasticstack_elasticsearch_index.first,
+    elasticstack_elasticsearch_index.second,
+  ]
+}
+	`, prefix, settings)
+}
+
+// checkIndexSetting checks the flat setting of the index, an empty expected value meaning the setting isn't set.
+func checkIndexSetting(indexName, key, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := clients.NewAcceptanceTestingClient()
+		if err != nil {
+			return err
+		}
+		esClient, err := client.GetESClient()
+		if err != nil {
+			return err
+		}
+		res, err := esClient.Indices.GetSettings(esClient.Indices.GetSettings.WithIndex(indexName), esClient.Indices.GetSettings.WithFlatSettings(true))
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if res.IsError() {
+			return fmt.Errorf("failed to get the settings of %s: %s", indexName, res.String())
+		}
+		var indices map[string]struct {
+			Settings map[string]interface{} `json:"settings"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&indices); err != nil {
+			return err
+		}
+		value := ""
+		if v, ok := indices[indexName].Settings[key]; ok {
+			value = fmt.Sprintf("%v", v)
+		}
+		if value != expected {
+			return fmt.Errorf("expected %s of %s to be %q, got %q", key, indexName, expected, value)
+		}
+		return nil
+	}
+}
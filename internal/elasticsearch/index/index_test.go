@@ -2,8 +2,11 @@ package index_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
@@ -132,6 +135,228 @@ func TestAccResourceIndexSettingsMigration(t *testing.T) {
 	})
 }
 
+func TestAccResourceIndexSettingsDrift(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexSettingsDrift(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_settings_drift", "number_of_replicas", "1"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_settings_drift", "refresh_interval", "10s"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_settings_drift", "blocks_write", "false"),
+				),
+			},
+			{
+				PreConfig: func() {
+					updateIndexSettings(t, indexName, `{"index.number_of_replicas": 2, "index.refresh_interval": "30s", "index.blocks.write": true}`)
+				},
+				Config:             testAccResourceIndexSettingsDrift(indexName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccResourceIndexSettingsDrift(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_settings_drift", "number_of_replicas", "1"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_settings_drift", "refresh_interval", "10s"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_settings_drift", "blocks_write", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexMappingMigration(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexMappingMigration(indexName, "keyword", "open"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_migration", "name", indexName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_migration", "concrete_name", indexName),
+				),
+			},
+			{
+				PreConfig: func() {
+					indexDocument(t, indexName, `{"field1": "value"}`)
+				},
+				Config: testAccResourceIndexMappingMigration(indexName, "text", "open"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_migration", "name", indexName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_migration", "concrete_name", indexName+"-000002"),
+					// the identifier stays keyed on the name
+					resource.TestMatchResourceAttr("elasticstack_elasticsearch_index.test_migration", "id", regexp.MustCompile("/"+indexName+"$")),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_migration", "mappings", `{"properties":{"field1":{"type":"text"}}}`),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_migration", "alias.#", "1"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_migration", "alias.0.name", indexName+"_alias"),
+					checkIndexDocumentsCount(indexName+"_alias", 1),
+					// the old index is replaced by an alias
+					checkIndexAlias(indexName+"-000002", indexName),
+				),
+			},
+			{
+				// the documents written to the name of the index are migrated too
+				PreConfig: func() {
+					indexDocument(t, indexName, `{"field1": 1}`)
+				},
+				Config: testAccResourceIndexMappingMigration(indexName, "long", "open"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_migration", "concrete_name", indexName+"-000003"),
+					checkIndexDocumentsCount(indexName+"_alias", 2),
+					checkIndexAlias(indexName+"-000003", indexName),
+					checkIndexMissing(indexName+"-000002"),
+				),
+			},
+			{
+				// the name resolves to the migrated index on import
+				ResourceName:      "elasticstack_elasticsearch_index.test_migration",
+				ImportState:       true,
+				ImportStateId:     indexName,
+				ImportStateVerify: true,
+				// the operation parameters aren't imported, the settings are imported whether configured or not
+				ImportStateVerifyIgnore: []string{"deletion_protection", "mapping_migration", "close_to_update_static_settings", "include_type_name", "master_timeout", "timeout", "wait_for_active_shards", "number_of_replicas", "number_of_shards"},
+			},
+			{
+				// the state is applied to the new index
+				Config: testAccResourceIndexMappingMigration(indexName, "keyword", "closed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_migration", "concrete_name", indexName+"-000004"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_migration", "state", "closed"),
+					checkIndexStatus(indexName+"-000004", "close"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexMappingMigrationRollback(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexMappingMigrationPipeline(indexName, "keyword", ""),
+			},
+			{
+				// the reindex fails without the ingest pipeline
+				Config:      testAccResourceIndexMappingMigrationPipeline(indexName, "text", "missing"),
+				ExpectError: regexp.MustCompile(`Unable to reindex`),
+			},
+			{
+				// the new index is deleted and the writes to the old index are unblocked
+				PreConfig: func() {
+					indexDocument(t, indexName, `{"field1": "value"}`)
+				},
+				Config: testAccResourceIndexMappingMigrationPipeline(indexName, "keyword", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_migration", "concrete_name", indexName),
+					checkIndexSetting(indexName, "index.blocks.write", ""),
+					checkIndexDocumentsCount(indexName, 1),
+					checkIndexMissing(indexName+"-000002"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexMappingChanges(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexMappingChanges(indexName, "standard", "standard", false, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "name", indexName),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_index.test", "mappings"),
+				),
+			},
+			{
+				// the search analyzer, the multi-fields and the runtime fields are updated in place
+				Config: testAccResourceIndexMappingChanges(indexName, "standard", "simple", true, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "concrete_name", indexName),
+					checkIndexMappings(indexName, `{"properties":{"field1":{"type":"text","analyzer":"standard","search_analyzer":"simple","fields":{"raw":{"type":"keyword"}}}}}`),
+				),
+			},
+			{
+				Config:      testAccResourceIndexMappingChanges(indexName, "simple", "simple", true, true),
+				ExpectError: regexp.MustCompile(`the mappings changes \[properties\.field1\.analyzer\] can't be applied`),
+			},
+			{
+				Config: testAccResourceIndexMappingChanges(indexName, "standard", "simple", true, false),
+			},
+		},
+	})
+}
+
+func TestAccResourceIndexState(t *testing.T) {
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexState(indexName, "standard", "open", false, false, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "state", "open"),
+					checkIndexSetting(indexName, "index.analysis.analyzer.custom.tokenizer", "standard"),
+				),
+			},
+			{
+				Config:      testAccResourceIndexState(indexName, "whitespace", "open", false, false, true),
+				ExpectError: regexp.MustCompile(`the analysis settings \[analysis_analyzer\] can only be updated on a closed index`),
+			},
+			{
+				// the analysis settings are updated once the index is closed
+				Config: testAccResourceIndexState(indexName, "whitespace", "closed", false, false, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "state", "closed"),
+					checkIndexStatus(indexName, "close"),
+					checkIndexSetting(indexName, "index.analysis.analyzer.custom.tokenizer", "whitespace"),
+				),
+			},
+			{
+				// the static settings are updated in place, closing the index temporarily
+				Config: testAccResourceIndexState(indexName, "standard", "open", true, false, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test", "state", "open"),
+					checkIndexStatus(indexName, "open"),
+					checkIndexSetting(indexName, "index.analysis.analyzer.custom.tokenizer", "standard"),
+				),
+			},
+			{
+				Config: testAccResourceIndexState(indexName, "standard", "open", true, true, true),
+				Check: resource.ComposeTestCheckFunc(
+					checkIndexStatus(indexName, "open"),
+					checkIndexSetting(indexName, "index.codec", "best_compression"),
+				),
+			},
+			{
+				Config: testAccResourceIndexState(indexName, "standard", "open", true, true, false),
+			},
+		},
+	})
+}
+
 func TestAccResourceIndexSettingsConflict(t *testing.T) {
 	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
@@ -350,6 +575,307 @@ resource "elasticstack_elasticsearch_index" "test_settings_migration" {
 	`, name)
 }
 
+func testAccResourceIndexSettingsDrift(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_settings_drift" {
+  name = "%s"
+
+  number_of_replicas = 1
+  refresh_interval   = "10s"
+  blocks_write       = false
+
+  deletion_protection = false
+}
+	`, name)
+}
+
+func updateIndexSettings(t *testing.T, indexName, settings string) {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	esClient, err := client.GetESClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := esClient.Indices.PutSettings(strings.NewReader(settings), esClient.Indices.PutSettings.WithIndex(indexName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		t.Fatalf("failed to update the settings of index %s: %s", indexName, res.String())
+	}
+}
+
+func testAccResourceIndexMappingMigration(name, fieldType, state string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_migration" {
+  name = "%[1]s"
+
+  alias {
+    name = "%[1]s_alias"
+  }
+
+  mappings = jsonencode({
+    properties = {
+      field1 = { type = "%[2]s" }
+    }
+  })
+
+  mapping_migration {
+    timeout = "5m"
+  }
+
+  state               = "%[3]s"
+  deletion_protection = false
+}
+	`, name, fieldType, state)
+}
+
+func testAccResourceIndexMappingMigrationPipeline(name, fieldType, pipeline string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_migration" {
+  name = "%s"
+
+  mappings = jsonencode({
+    properties = {
+      field1 = { type = "%s" }
+    }
+  })
+
+  mapping_migration {
+    pipeline = "%s"
+  }
+
+  deletion_protection = false
+}
+	`, name, fieldType, pipeline)
+}
+
+func indexDocument(t *testing.T, indexName, document string) {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	esClient, err := client.GetESClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := esClient.Index(indexName, strings.NewReader(document), esClient.Index.WithRefresh("true"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		t.Fatalf("failed to index a document in %s: %s", indexName, res.String())
+	}
+}
+
+func checkIndexDocumentsCount(indexName string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := clients.NewAcceptanceTestingClient()
+		if err != nil {
+			return err
+		}
+		esClient, err := client.GetESClient()
+		if err != nil {
+			return err
+		}
+		if res, err := esClient.Indices.Refresh(esClient.Indices.Refresh.WithIndex(indexName)); err == nil {
+			res.Body.Close()
+		}
+		res, err := esClient.Count(esClient.Count.WithIndex(indexName))
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if res.IsError() {
+			return fmt.Errorf("failed to count the documents of %s: %s", indexName, res.String())
+		}
+		var count struct {
+			Count int `json:"count"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&count); err != nil {
+			return err
+		}
+		if count.Count != expected {
+			return fmt.Errorf("expected %d documents in %s, got %d", expected, indexName, count.Count)
+		}
+		return nil
+	}
+}
+
+func checkIndexMappings(indexName, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := clients.NewAcceptanceTestingClient()
+		if err != nil {
+			return err
+		}
+		esClient, err := client.GetESClient()
+		if err != nil {
+			return err
+		}
+		res, err := esClient.Indices.GetMapping(esClient.Indices.GetMapping.WithIndex(indexName))
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if res.IsError() {
+			return fmt.Errorf("failed to get the mappings of %s: %s", indexName, res.String())
+		}
+		var indices map[string]struct {
+			Mappings map[string]interface{} `json:"mappings"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&indices); err != nil {
+			return err
+		}
+		var want map[string]interface{}
+		if err := json.Unmarshal([]byte(expected), &want); err != nil {
+			return err
+		}
+		if got := indices[indexName].Mappings; !reflect.DeepEqual(got, want) {
+			return fmt.Errorf("expected mappings %v for %s, got %v", want, indexName, got)
+		}
+		return nil
+	}
+}
+
+func checkIndexMissing(indexName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := clients.NewAcceptanceTestingClient()
+		if err != nil {
+			return err
+		}
+		esClient, err := client.GetESClient()
+		if err != nil {
+			return err
+		}
+		res, err := esClient.Indices.Get([]string{indexName})
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if res.StatusCode != 404 {
+			return fmt.Errorf("Index (%s) still exists", indexName)
+		}
+		return nil
+	}
+}
+
+func testAccResourceIndexMappingChanges(name, analyzer, searchAnalyzer string, updated, deletionProtection bool) string {
+	mappings := fmt.Sprintf(`{
+    properties = {
+      field1 = {
+        type            = "text"
+        analyzer        = "%s"
+        search_analyzer = "%s"
+      }
+    }
+    runtime = {
+      day_of_week = { type = "keyword" }
+    }
+  }`, analyzer, searchAnalyzer)
+	if updated {
+		mappings = fmt.Sprintf(`{
+    properties = {
+      field1 = {
+        type            = "text"
+        analyzer        = "%s"
+        search_analyzer = "%s"
+        fields = {
+          raw = { type = "keyword" }
+        }
+      }
+    }
+  }`, analyzer, searchAnalyzer)
+	}
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name                = "%s"
+  deletion_protection = %t
+
+  mappings = jsonencode(%s)
+}
+	`, name, deletionProtection, mappings)
+}
+
+func testAccResourceIndexState(name, tokenizer, state string, closeToUpdate, bestCompression, deletionProtection bool) string {
+	codec := ""
+	if bestCompression {
+		codec = `codec = "best_compression"`
+	}
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  name                = "%s"
+  state               = "%s"
+  deletion_protection = %t
+
+  close_to_update_static_settings = %t
+  %s
+
+  analysis_analyzer = jsonencode({
+    custom = {
+      type      = "custom"
+      tokenizer = "%s"
+    }
+  })
+}
+	`, name, state, deletionProtection, closeToUpdate, codec, tokenizer)
+}
+
+// checkIndexStatus checks the status of the index, `open` or `close`.
+func checkIndexStatus(indexName, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := clients.NewAcceptanceTestingClient()
+		if err != nil {
+			return err
+		}
+		esClient, err := client.GetESClient()
+		if err != nil {
+			return err
+		}
+		res, err := esClient.Cat.Indices(esClient.Cat.Indices.WithIndex(indexName), esClient.Cat.Indices.WithFormat("json"), esClient.Cat.Indices.WithH("status"))
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if res.IsError() {
+			return fmt.Errorf("failed to get the status of %s: %s", indexName, res.String())
+		}
+		var indices []struct {
+			Status string `json:"status"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&indices); err != nil {
+			return err
+		}
+		if len(indices) != 1 || indices[0].Status != expected {
+			return fmt.Errorf("expected the status of %s to be %s, got %v", indexName, expected, indices)
+		}
+		return nil
+	}
+}
+
 func testAccResourceIndexSettingsConflict(name string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
@@ -448,7 +974,7 @@ func checkResourceIndexDestroy(s *terraform.State) error {
 	}
 
 	for _, rs := range s.RootModule().Resources {
-		if rs.Type != "elasticstack_elasticsearch_index" {
+		if rs.Type != "elasticstack_elasticsearch_index" && rs.Type != "elasticstack_elasticsearch_index_resize" {
 			continue
 		}
 		compId, _ := clients.CompositeIdFromStr(rs.Primary.ID)
@@ -559,6 +1085,105 @@ func Test_IsMappingForceNewRequired(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "return true when analyzer is changed",
+			old: map[string]interface{}{
+				"field1": map[string]interface{}{
+					"type":     "text",
+					"analyzer": "standard",
+				},
+			},
+			new: map[string]interface{}{
+				"field1": map[string]interface{}{
+					"type":     "text",
+					"analyzer": "english",
+				},
+			},
+			want: true,
+		},
+		{
+			name: "return false when search_analyzer is changed",
+			old: map[string]interface{}{
+				"field1": map[string]interface{}{
+					"type":            "text",
+					"search_analyzer": "standard",
+				},
+			},
+			new: map[string]interface{}{
+				"field1": map[string]interface{}{
+					"type":            "text",
+					"search_analyzer": "english",
+				},
+			},
+			want: false,
+		},
+		{
+			name: "return false when norms are disabled",
+			old: map[string]interface{}{
+				"field1": map[string]interface{}{
+					"type": "text",
+				},
+			},
+			new: map[string]interface{}{
+				"field1": map[string]interface{}{
+					"type":  "text",
+					"norms": false,
+				},
+			},
+			want: false,
+		},
+		{
+			name: "return true when norms are enabled back",
+			old: map[string]interface{}{
+				"field1": map[string]interface{}{
+					"type":  "text",
+					"norms": false,
+				},
+			},
+			new: map[string]interface{}{
+				"field1": map[string]interface{}{
+					"type": "text",
+				},
+			},
+			want: true,
+		},
+		{
+			name: "return false when multi-field is added",
+			old: map[string]interface{}{
+				"field1": map[string]interface{}{
+					"type": "text",
+				},
+			},
+			new: map[string]interface{}{
+				"field1": map[string]interface{}{
+					"type": "text",
+					"fields": map[string]interface{}{
+						"raw": map[string]interface{}{"type": "keyword"},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "return true when multi-field type is changed",
+			old: map[string]interface{}{
+				"field1": map[string]interface{}{
+					"type": "text",
+					"fields": map[string]interface{}{
+						"raw": map[string]interface{}{"type": "keyword"},
+					},
+				},
+			},
+			new: map[string]interface{}{
+				"field1": map[string]interface{}{
+					"type": "text",
+					"fields": map[string]interface{}{
+						"raw": map[string]interface{}{"type": "wildcard"},
+					},
+				},
+			},
+			want: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
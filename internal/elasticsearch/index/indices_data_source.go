@@ -0,0 +1,90 @@
+package index
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceIndices() *schema.Resource {
+	indicesSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"pattern": {
+			Description: "Comma separated list of index names, aliases, data streams and wildcard patterns, e.g. `logs-*`.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"expand_wildcards": {
+			Description:  "Comma separated list of the states of the indices the wildcard patterns can match: `all`, `open`, `closed`, `hidden` or `none`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "open",
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(all|open|closed|hidden|none)(,(all|open|closed|hidden|none))*$`), "must be a comma separated list of all, open, closed, hidden or none"),
+		},
+		"indices": {
+			Description: "Indices matching the pattern, sorted by name.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: indexDataSourceAttributes(),
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(indicesSchema)
+
+	return &schema.Resource{
+		Description: "Gets information about the indices matching a pattern, which don't need to be managed by Terraform. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-get-index.html",
+
+		ReadContext: dataSourceIndicesRead,
+
+		Schema: indicesSchema,
+	}
+}
+
+func dataSourceIndicesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	pattern := d.Get("pattern").(string)
+	expandWildcards := d.Get("expand_wildcards").(string)
+	id, diags := client.ID(ctx, pattern)
+	if diags.HasError() {
+		return diags
+	}
+
+	indices, diags := elasticsearch.GetIndices(ctx, client, pattern, expandWildcards)
+	if diags.HasError() {
+		return diags
+	}
+	stats, diags := elasticsearch.GetIndicesStats(ctx, client, pattern, expandWildcards)
+	if diags.HasError() {
+		return diags
+	}
+
+	result := make([]interface{}, 0, len(indices))
+	for _, name := range sortedKeys(indices) {
+		index, diags := flattenIndexDataSource(indices[name], stats[name])
+		if diags.HasError() {
+			return diags
+		}
+		result = append(result, index)
+	}
+	if err := d.Set("indices", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
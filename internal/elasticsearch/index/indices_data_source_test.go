@@ -0,0 +1,77 @@
+package index_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceIndices(t *testing.T) {
+	prefix := sdkacctest.RandStringFromCharSet(16, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceIndices(prefix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.#", "2"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.0.name", prefix+"-000001"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.1.name", prefix+"-000002"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.0.status", "open"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_indices.test", "indices.1.uuid"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.1.alias.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.1.alias.0.is_write_index", "true"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.none", "indices.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceIndices(prefix string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "first" {
+  name                = "%[1]s-000001"
+  deletion_protection = false
+}
+
+resource "elasticstack_elasticsearch_index" "second" {
+  name = "%[1]s-000002"
+
+  alias {
+    name           = "%[1]s"
+    is_write_index = true
+  }
+
+  deletion_protection = false
+}
+
+data "elasticstack_elasticsearch_indices" "test" {
+  pattern = "%[1]s-*"
+
+  depends_on = [
+    elasticstack_elasticsearch_index.first,
+    elasticstack_elasticsearch_index.second,
+  ]
+}
+
+data "elasticstack_elasticsearch_indices" "none" {
+  pattern          = "%[1]s-*"
+  expand_wildcards = "closed"
+
+  depends_on = [
+    elasticstack_elasticsearch_index.first,
+    elasticstack_elasticsearch_index.second,
+  ]
+}
+	`, prefix)
+}
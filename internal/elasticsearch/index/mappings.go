@@ -0,0 +1,311 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// mappingParameterRule reports whether changing the mapping parameter from old to new requires a new index,
+// the values being the default ones when the parameter isn't defined, or nil without default.
+type mappingParameterRule func(old, new interface{}) bool
+
+func immutable(old, new interface{}) bool {
+	return !reflect.DeepEqual(old, new)
+}
+
+func updatable(_, _ interface{}) bool {
+	return false
+}
+
+// disableOnly allows disabling a feature on an existing field, but not enabling it back.
+func disableOnly(old, new interface{}) bool {
+	return old == false && new != false
+}
+
+// mappingParameterRules holds the rules of the mapping parameters of the fields, keyed by field type. The rules
+// of the `*` key apply to all the types unless overridden. The parameters without rule are left to Elasticsearch,
+// which fails the update if they can't be changed.
+// See https://www.elastic.co/guide/en/elasticsearch/reference/current/mapping-params.html
+var mappingParameterRules = map[string]map[string]mappingParameterRule{
+	"*": {
+		"analyzer":               immutable,
+		"doc_values":             immutable,
+		"enabled":                immutable,
+		"format":                 immutable,
+		"index":                  immutable,
+		"index_options":          immutable,
+		"index_phrases":          immutable,
+		"index_prefixes":         immutable,
+		"locale":                 immutable,
+		"normalizer":             immutable,
+		"null_value":             immutable,
+		"position_increment_gap": immutable,
+		"similarity":             immutable,
+		"store":                  immutable,
+		"term_vector":            immutable,
+		"norms":                  disableOnly,
+		"boost":                  updatable,
+		"coerce":                 updatable,
+		"copy_to":                updatable,
+		"dynamic":                updatable,
+		"eager_global_ordinals":  updatable,
+		"ignore_above":           updatable,
+		"ignore_malformed":       updatable,
+		"meta":                   updatable,
+		"search_analyzer":        updatable,
+		"search_quote_analyzer":  updatable,
+	},
+	"text": {
+		"fielddata":                  updatable,
+		"fielddata_frequency_filter": updatable,
+	},
+	"keyword": {
+		"split_queries_on_whitespace": updatable,
+	},
+	"nested": {
+		"include_in_parent": immutable,
+		"include_in_root":   immutable,
+	},
+	"scaled_float": {
+		"scaling_factor": immutable,
+	},
+	"dense_vector": {
+		"dims": immutable,
+	},
+}
+
+// rootMappingParameterRules holds the rules of the parameters at the root of the mappings. The `properties`,
+// `dynamic_templates` and `runtime` sections are handled separately.
+var rootMappingParameterRules = map[string]mappingParameterRule{
+	"_source":              immutable,
+	"_routing":             immutable,
+	"_meta":                updatable,
+	"dynamic":              updatable,
+	"date_detection":       updatable,
+	"numeric_detection":    updatable,
+	"dynamic_date_formats": updatable,
+}
+
+func mappingParameterRuleFor(fieldType, parameter string) mappingParameterRule {
+	if rule, ok := mappingParameterRules[fieldType][parameter]; ok {
+		return rule
+	}
+	return mappingParameterRules["*"][parameter]
+}
+
+// mappingParameterDefaults holds the default values of the mapping parameters with a rule, keyed by field type as
+// the rules. Elasticsearch leaves the parameters set to their default value out of the mappings it returns.
+var mappingParameterDefaults = map[string]map[string]interface{}{
+	"*": {
+		"doc_values":    true,
+		"enabled":       true,
+		"index":         true,
+		"index_phrases": false,
+		"norms":         false,
+		"store":         false,
+	},
+	"text": {
+		"norms": true,
+	},
+	"nested": {
+		"include_in_parent": false,
+		"include_in_root":   false,
+	},
+}
+
+// rootMappingParameterDefaults holds the default values of the parameters at the root of the mappings, the
+// default values of the object parameters being the ones of their sub-parameters.
+var rootMappingParameterDefaults = map[string]interface{}{
+	"_source":  map[string]interface{}{"enabled": true},
+	"_routing": map[string]interface{}{"required": false},
+}
+
+func mappingParameterDefaultFor(fieldType, parameter string) interface{} {
+	if value, ok := mappingParameterDefaults[fieldType][parameter]; ok {
+		return value
+	}
+	return mappingParameterDefaults["*"][parameter]
+}
+
+// withDefault returns the value of the parameter, or its default value when it isn't defined. The object values
+// are completed with the default values of their sub-parameters.
+func withDefault(value, defaultValue interface{}) interface{} {
+	defaults, ok := defaultValue.(map[string]interface{})
+	if !ok {
+		if value == nil {
+			return defaultValue
+		}
+		return value
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok && value != nil {
+		return value
+	}
+	result := make(map[string]interface{}, len(defaults)+len(object))
+	for k, v := range defaults {
+		result[k] = v
+	}
+	for k, v := range object {
+		result[k] = v
+	}
+	return result
+}
+
+// mappingsChangesRequiringNewIndex returns the paths of the changes between the old and new mappings which
+// can't be applied to the existing index.
+//
+// The dynamic templates and the runtime fields can always be updated, the fields removed from the mappings
+// are kept by Elasticsearch and ignored.
+func mappingsChangesRequiringNewIndex(ctx context.Context, old, new string) []string {
+	o := make(map[string]interface{})
+	if err := json.NewDecoder(strings.NewReader(old)).Decode(&o); err != nil {
+		return []string{"mappings"}
+	}
+	n := make(map[string]interface{})
+	if err := json.NewDecoder(strings.NewReader(new)).Decode(&n); err != nil {
+		return []string{"mappings"}
+	}
+	tflog.Trace(ctx, "mappings custom diff old = %+v new = %+v", o, n)
+
+	var changes []string
+	for _, parameter := range sortedParameters(o, n) {
+		rule, ok := rootMappingParameterRules[parameter]
+		if !ok {
+			continue
+		}
+		defaultValue := rootMappingParameterDefaults[parameter]
+		if rule(withDefault(o[parameter], defaultValue), withDefault(n[parameter], defaultValue)) {
+			changes = append(changes, parameter)
+		}
+	}
+
+	// if old defined we must check if the type of the existing fields were changed
+	if oldProps, ok := o["properties"]; ok {
+		newProps, ok := n["properties"]
+		// if the old has props but new one not, immediately force new resource
+		if !ok {
+			return append(changes, "properties")
+		}
+		changes = append(changes, propertiesChangesRequiringNewIndex(ctx, "properties", oldProps.(map[string]interface{}), newProps.(map[string]interface{}))...)
+	}
+	return changes
+}
+
+// propertiesChangesRequiringNewIndex returns the paths of the changes of the fields which can't be applied to
+// the existing index, recursing into the object properties and the multi-fields.
+func propertiesChangesRequiringNewIndex(ctx context.Context, path string, old, new map[string]interface{}) []string {
+	var changes []string
+	for _, name := range sortedKeys(old) {
+		fieldPath := path + "." + name
+		oldField, _ := old[name].(map[string]interface{})
+		newValue, ok := new[name]
+		// When field is removed, it'll be ignored in elasticsearch
+		if !ok {
+			tflog.Warn(ctx, fmt.Sprintf("removing %s field in mappings is ignored. Re-index to remove the field completely.", fieldPath))
+			continue
+		}
+		newField, _ := newValue.(map[string]interface{})
+
+		oldType, newType := fieldType(oldField), fieldType(newField)
+		if oldType != newType {
+			changes = append(changes, fieldPath+".type")
+			continue
+		}
+
+		for _, parameter := range sortedParameters(oldField, newField) {
+			rule := mappingParameterRuleFor(oldType, parameter)
+			if rule == nil {
+				continue
+			}
+			defaultValue := mappingParameterDefaultFor(oldType, parameter)
+			if rule(withDefault(oldField[parameter], defaultValue), withDefault(newField[parameter], defaultValue)) {
+				changes = append(changes, fieldPath+"."+parameter)
+			}
+		}
+
+		// if we have "mapping" field, let's call ourself to check again
+		for _, section := range []string{"properties", "fields"} {
+			oldSection, ok := oldField[section].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			newSection, ok := newField[section].(map[string]interface{})
+			if !ok {
+				tflog.Warn(ctx, fmt.Sprintf("removing %s.%s in mappings is ignored, if you neeed to remove it completely, please recreate the index", fieldPath, section))
+				continue
+			}
+			changes = append(changes, propertiesChangesRequiringNewIndex(ctx, fieldPath+"."+section, oldSection, newSection)...)
+		}
+	}
+	return changes
+}
+
+// fieldType returns the type of the field, the fields without type being objects.
+func fieldType(field map[string]interface{}) string {
+	if t, ok := field["type"].(string); ok {
+		return t
+	}
+	return "object"
+}
+
+// sortedParameters returns the parameters defined in either of the definitions, except the type and the
+// nested fields definitions.
+func sortedParameters(old, new map[string]interface{}) []string {
+	parameters := map[string]interface{}{}
+	for _, definition := range []map[string]interface{}{old, new} {
+		for k, v := range definition {
+			switch k {
+			case "type", "properties", "fields", "dynamic_templates", "runtime":
+				continue
+			}
+			parameters[k] = v
+		}
+	}
+	return sortedKeys(parameters)
+}
+
+// mappingsUpdateBody returns the body of the update mapping request. The update mapping API merges the mappings
+// with the existing ones, the removed dynamic templates and runtime fields must be removed explicitly.
+func mappingsUpdateBody(old, new string) (string, error) {
+	o := make(map[string]interface{})
+	if old != "" {
+		if err := json.Unmarshal([]byte(old), &o); err != nil {
+			return "", err
+		}
+	}
+	n := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(new), &n); err != nil {
+		return "", err
+	}
+
+	if _, ok := o["dynamic_templates"]; ok {
+		if _, ok := n["dynamic_templates"]; !ok {
+			n["dynamic_templates"] = []interface{}{}
+		}
+	}
+	if oldRuntime, ok := o["runtime"].(map[string]interface{}); ok {
+		newRuntime, ok := n["runtime"].(map[string]interface{})
+		if !ok {
+			newRuntime = map[string]interface{}{}
+		}
+		for field := range oldRuntime {
+			if _, ok := newRuntime[field]; !ok {
+				newRuntime[field] = nil
+			}
+		}
+		if len(newRuntime) > 0 {
+			n["runtime"] = newRuntime
+		}
+	}
+
+	body, err := json.Marshal(n)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
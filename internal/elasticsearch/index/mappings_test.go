@@ -0,0 +1,129 @@
+package index
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestMappingsChangesRequiringNewIndex(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		old      string
+		new      string
+		expected []string
+	}{
+		{
+			name: "ignores the parameters set to their omitted default value",
+			old:  `{"properties":{"field1":{"type":"keyword"},"object1":{"properties":{"field2":{"type":"long"}}}}}`,
+			new:  `{"properties":{"field1":{"type":"keyword","doc_values":true,"index":true,"store":false,"norms":false},"object1":{"type":"object","enabled":true,"properties":{"field2":{"type":"long"}}}}}`,
+		},
+		{
+			name:     "changes the immutable parameters from their omitted default value",
+			old:      `{"properties":{"field1":{"type":"keyword"}}}`,
+			new:      `{"properties":{"field1":{"type":"keyword","doc_values":false,"index":false}}}`,
+			expected: []string{"properties.field1.doc_values", "properties.field1.index"},
+		},
+		{
+			name:     "resets the immutable parameters to their default value",
+			old:      `{"properties":{"field1":{"type":"keyword","store":true}}}`,
+			new:      `{"properties":{"field1":{"type":"keyword"}}}`,
+			expected: []string{"properties.field1.store"},
+		},
+		{
+			name:     "changes the type of a field",
+			old:      `{"properties":{"field1":{"type":"keyword"}}}`,
+			new:      `{"properties":{"field1":{"type":"text"}}}`,
+			expected: []string{"properties.field1.type"},
+		},
+		{
+			name: "updates the updatable parameters",
+			old:  `{"properties":{"field1":{"type":"text","analyzer":"standard"}}}`,
+			new:  `{"properties":{"field1":{"type":"text","analyzer":"standard","search_analyzer":"simple","ignore_above":256}}}`,
+		},
+		{
+			name: "ignores the removed fields",
+			old:  `{"properties":{"field1":{"type":"keyword"},"field2":{"type":"long"}}}`,
+			new:  `{"properties":{"field1":{"type":"keyword"}}}`,
+		},
+		{
+			name: "disables the norms of a text field",
+			old:  `{"properties":{"field1":{"type":"text"}}}`,
+			new:  `{"properties":{"field1":{"type":"text","norms":false}}}`,
+		},
+		{
+			name:     "enables back the norms of a text field",
+			old:      `{"properties":{"field1":{"type":"text","norms":false}}}`,
+			new:      `{"properties":{"field1":{"type":"text"}}}`,
+			expected: []string{"properties.field1.norms"},
+		},
+		{
+			name:     "enables the norms of a keyword field",
+			old:      `{"properties":{"field1":{"type":"keyword"}}}`,
+			new:      `{"properties":{"field1":{"type":"keyword","norms":true}}}`,
+			expected: []string{"properties.field1.norms"},
+		},
+		{
+			name: "adds a multi-field",
+			old:  `{"properties":{"field1":{"type":"text"}}}`,
+			new:  `{"properties":{"field1":{"type":"text","fields":{"raw":{"type":"keyword","doc_values":true}}}}}`,
+		},
+		{
+			name:     "changes a multi-field",
+			old:      `{"properties":{"field1":{"type":"text","fields":{"raw":{"type":"keyword"}}}}}`,
+			new:      `{"properties":{"field1":{"type":"text","fields":{"raw":{"type":"keyword","index":false}}}}}`,
+			expected: []string{"properties.field1.fields.raw.index"},
+		},
+		{
+			name:     "changes a field of an object",
+			old:      `{"properties":{"object1":{"properties":{"field1":{"type":"keyword"}}}}}`,
+			new:      `{"properties":{"object1":{"properties":{"field1":{"type":"long"}}}}}`,
+			expected: []string{"properties.object1.properties.field1.type"},
+		},
+		{
+			name: "ignores the source enabled by default",
+			old:  `{"properties":{"field1":{"type":"keyword"}}}`,
+			new:  `{"_source":{"enabled":true},"properties":{"field1":{"type":"keyword"}}}`,
+		},
+		{
+			name:     "disables the source",
+			old:      `{"properties":{"field1":{"type":"keyword"}}}`,
+			new:      `{"_source":{"enabled":false},"properties":{"field1":{"type":"keyword"}}}`,
+			expected: []string{"_source"},
+		},
+		{
+			name: "updates the root updatable parameters",
+			old:  `{"dynamic":"true"}`,
+			new:  `{"dynamic":"strict","_meta":{"version":2},"dynamic_templates":[{"strings":{"match_mapping_type":"string","mapping":{"type":"keyword"}}}]}`,
+		},
+		{
+			name:     "removes all the fields",
+			old:      `{"properties":{"field1":{"type":"keyword"}}}`,
+			new:      `{}`,
+			expected: []string{"properties"},
+		},
+		{
+			name:     "fails to parse the mappings",
+			old:      `{"properties":{}}`,
+			new:      `{`,
+			expected: []string{"mappings"},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			changes := mappingsChangesRequiringNewIndex(context.Background(), tc.old, tc.new)
+			if len(changes) == 0 && len(tc.expected) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(changes, tc.expected) {
+				t.Errorf("expected the changes %v, got %v", tc.expected, changes)
+			}
+		})
+	}
+}
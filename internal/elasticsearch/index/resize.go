@@ -0,0 +1,293 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceIndexResize() *schema.Resource {
+	resizeSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"type": {
+			Description:  "The resize operation: `shrink` to reduce the number of primary shards, `split` to increase it, or `clone` to copy the index with the same number of shards.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{"shrink", "split", "clone"}, false),
+		},
+		"source_index": {
+			Description: "Name of the source index, which is left in place.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"target_index": {
+			Description: "Name of the target index created by the operation.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			ValidateFunc: validation.All(
+				validation.StringLenBetween(1, 255),
+				validation.StringNotInSlice([]string{".", ".."}, true),
+				validation.StringMatch(regexp.MustCompile(`^[^-_+]`), "cannot start with -, _, +"),
+				validation.StringMatch(regexp.MustCompile(`^[a-z0-9!$%&'()+.;=@[\]^{}~_-]+$`), "must contain lower case alphanumeric characters and selected punctuation, see: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-create-index.html#indices-create-api-path-params"),
+			),
+		},
+		"number_of_shards": {
+			Description: "Number of primary shards of the target index. It must be a factor of the number of shards of the source index for `shrink`, defaulting to 1, and a multiple of it for `split`. The number of shards of the source index is kept for `clone`.",
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+		},
+		"settings": {
+			Description:      "Additional settings of the target index as JSON, e.g. the number of replicas. The other settings are copied from the source index.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+			ValidateFunc:     validation.StringIsJSON,
+		},
+		"move_aliases": {
+			Description: "If true, the aliases of the source index are moved to the target index in a single atomic call once it is created.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			ForceNew:    true,
+		},
+		"wait_for_status": {
+			Description:  "Health status the source index must reach before the operation, and the target index after it: `green` or `yellow`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "green",
+			ValidateFunc: validation.StringInSlice([]string{"green", "yellow"}, false),
+		},
+		"timeout": {
+			Description:  "Period to wait for each of the health status and the shards relocation. Defaults to `30m`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "30m",
+			ValidateFunc: utils.StringIsDuration,
+		},
+		"deletion_protection": {
+			Description: "Whether to allow Terraform to destroy the target index. Unless this field is set to false in Terraform state, a terraform destroy or terraform apply command that deletes the target index will fail.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+	}
+
+	utils.AddConnectionSchema(resizeSchema)
+
+	return &schema.Resource{
+		Description: "Shrinks, splits or clones an index into a new target index, taking care of the prerequisites of the operation. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-shrink-index.html, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-split-index.html and https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-clone-index.html",
+
+		CreateContext: resourceIndexResizeCreate,
+		UpdateContext: resourceIndexResizeRead,
+		ReadContext:   resourceIndexResizeRead,
+		DeleteContext: resourceIndexResizeDelete,
+
+		Schema: resizeSchema,
+	}
+}
+
+func resourceIndexResizeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	resizeType := d.Get("type").(string)
+	source := d.Get("source_index").(string)
+	target := d.Get("target_index").(string)
+	status := d.Get("wait_for_status").(string)
+	id, diags := client.ID(ctx, target)
+	if diags.HasError() {
+		return diags
+	}
+	timeout, err := time.ParseDuration(d.Get("timeout").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	targetSettings := map[string]interface{}{}
+	if v := d.Get("settings").(string); v != "" {
+		if err := json.Unmarshal([]byte(v), &targetSettings); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if v, ok := d.GetOk("number_of_shards"); ok {
+		targetSettings["index.number_of_shards"] = v
+	}
+
+	sourceIndex, diags := elasticsearch.GetIndex(ctx, client, source)
+	if diags.HasError() {
+		return diags
+	}
+	if sourceIndex == nil {
+		return diag.Errorf(`Source index "%s" not found`, source)
+	}
+
+	// the source index must be read-only, and for a shrink have a copy of all its shards on the same node
+	prerequisites := map[string]interface{}{"index.blocks.write": true}
+	if resizeType == "shrink" {
+		shards, diags := elasticsearch.GetIndexShards(ctx, client, source)
+		if diags.HasError() {
+			return diags
+		}
+		node, diags := shrinkNode(source, shards)
+		if diags.HasError() {
+			return diags
+		}
+		prerequisites["index.routing.allocation.require._name"] = node
+	}
+	// the prerequisites are copied to the target index, where they are reset
+	previousSettings := make(map[string]interface{}, len(prerequisites))
+	for key := range prerequisites {
+		previousSettings[key], _ = lookupIndexSetting(sourceIndex.Settings, key)
+		if _, ok := targetSettings[key]; !ok {
+			targetSettings[key] = nil
+		}
+	}
+
+	tflog.Info(ctx, fmt.Sprintf(`Preparing index "%s" to %s it into "%s"`, source, resizeType, target))
+	if diags := elasticsearch.UpdateIndexSettings(ctx, client, source, prerequisites); diags.HasError() {
+		return diags
+	}
+	// the source index is restored whatever the outcome of the operation
+	restoreSource := func(diags diag.Diagnostics) diag.Diagnostics {
+		return append(diags, elasticsearch.UpdateIndexSettings(ctx, client, source, previousSettings)...)
+	}
+
+	if diags := elasticsearch.WaitForIndexHealth(ctx, client, source, status, resizeType == "shrink", timeout); diags.HasError() {
+		return restoreSource(diags)
+	}
+	if diags := elasticsearch.ResizeIndex(ctx, client, resizeType, source, target, targetSettings, "1"); diags.HasError() {
+		return restoreSource(diags)
+	}
+	d.SetId(id.String())
+	if diags := elasticsearch.WaitForIndexHealth(ctx, client, target, status, false, timeout); diags.HasError() {
+		return restoreSource(diags)
+	}
+
+	if d.Get("move_aliases").(bool) && len(sourceIndex.Aliases) > 0 {
+		var actions []models.AliasAction
+		for _, name := range sortedKeys(sourceIndex.Aliases) {
+			alias := sourceIndex.Aliases[name]
+			alias.Name = name
+			actions = append(actions,
+				models.AliasAction{Type: "remove", Index: source, Alias: models.IndexAlias{Name: name}},
+				models.AliasAction{Type: "add", Index: target, Alias: alias},
+			)
+		}
+		if diags := elasticsearch.UpdateAliases(ctx, client, actions); diags.HasError() {
+			return restoreSource(diags)
+		}
+	}
+
+	if diags := restoreSource(nil); diags.HasError() {
+		return diags
+	}
+	return resourceIndexResizeRead(ctx, d, meta)
+}
+
+// shrinkNode returns the node to relocate the shards of the index to before shrinking it, the node holding the
+// most primary shards to limit the relocations.
+func shrinkNode(index string, shards []models.IndexShard) (string, diag.Diagnostics) {
+	primaries := map[string]int{}
+	for _, shard := range shards {
+		if shard.Node == "" || shard.State != "STARTED" {
+			continue
+		}
+		if _, ok := primaries[shard.Node]; !ok {
+			primaries[shard.Node] = 0
+		}
+		if shard.Prirep == "p" {
+			primaries[shard.Node]++
+		}
+	}
+	if len(primaries) == 0 {
+		return "", diag.Errorf(`No started shard found for index "%s"`, index)
+	}
+	nodes := sortedKeys(primaries)
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return primaries[nodes[i]] > primaries[nodes[j]]
+	})
+	return nodes[0], nil
+}
+
+func resourceIndexResizeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	target := compId.ResourceId
+
+	index, diags := elasticsearch.GetIndex(ctx, client, target)
+	if index == nil && diags == nil {
+		tflog.Warn(ctx, fmt.Sprintf(`Index "%s" not found, removing from state`, target))
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("target_index", target); err != nil {
+		return diag.FromErr(err)
+	}
+	if v, ok := lookupIndexSetting(index.Settings, "number_of_shards"); ok {
+		shards, err := strconv.Atoi(fmt.Sprintf("%v", v))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("number_of_shards", shards); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if v, ok := lookupIndexSetting(index.Settings, "resize.source.name"); ok {
+		if err := d.Set("source_index", v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return diags
+}
+
+func resourceIndexResizeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("cannot destroy index without setting deletion_protection=false and running `terraform apply`")
+	}
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	if diags := elasticsearch.DeleteIndex(ctx, client, compId.ResourceId); diags.HasError() {
+		return diags
+	}
+	return diags
+}
@@ -0,0 +1,105 @@
+package index_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceIndexResizeShrink(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(16, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexResizeSource(name),
+			},
+			{
+				Config: testAccResourceIndexResizeShrink(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_resize.test", "target_index", name+"-shrunk"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_resize.test", "number_of_shards", "1"),
+					checkIndexSetting(name+"-shrunk", "index.number_of_shards", "1"),
+					checkIndexSetting(name+"-shrunk", "index.blocks.write", ""),
+					checkIndexSetting(name+"-shrunk", "index.routing.allocation.require._name", ""),
+					checkIndexSetting(name, "index.blocks.write", ""),
+					checkIndexSetting(name, "index.routing.allocation.require._name", ""),
+					checkIndexAlias(name+"-shrunk", name+"-alias"),
+				),
+			},
+			{
+				Config: testAccResourceIndexResizeShrink(name, false),
+				Check:  resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_resize.test", "deletion_protection", "false"),
+			},
+		},
+	})
+}
+
+func testAccResourceIndexResizeSource(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "source" {
+  name                = "%[1]s"
+  number_of_shards    = 2
+  number_of_replicas  = 0
+  deletion_protection = false
+
+  alias {
+    name = "%[1]s-alias"
+  }
+
+  # the alias is moved to the target index
+  lifecycle {
+    ignore_changes = [alias]
+  }
+}
+	`, name)
+}
+
+func testAccResourceIndexResizeShrink(name string, deletionProtection bool) string {
+	return testAccResourceIndexResizeSource(name) + fmt.Sprintf(`
+resource "elasticstack_elasticsearch_index_resize" "test" {
+  type             = "shrink"
+  source_index     = elasticstack_elasticsearch_index.source.name
+  target_index     = "%s-shrunk"
+  number_of_shards = 1
+  move_aliases     = true
+  wait_for_status  = "yellow"
+
+  deletion_protection = %t
+}
+	`, name, deletionProtection)
+}
+
+func checkIndexAlias(indexName, alias string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := clients.NewAcceptanceTestingClient()
+		if err != nil {
+			return err
+		}
+		esClient, err := client.GetESClient()
+		if err != nil {
+			return err
+		}
+		res, err := esClient.Indices.GetAlias(esClient.Indices.GetAlias.WithIndex(indexName), esClient.Indices.GetAlias.WithName(alias))
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if res.IsError() {
+			return fmt.Errorf("expected index %s to have the alias %s: %s", indexName, alias, res.String())
+		}
+		return nil
+	}
+}
@@ -174,7 +174,7 @@ func ResourceTemplate() *schema.Resource {
 		DeleteContext: resourceIndexTemplateDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStateContext,
 		},
 
 		Schema: templateSchema,
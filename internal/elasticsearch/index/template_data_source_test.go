@@ -0,0 +1,80 @@
+package index_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceIndexTemplate(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceIndexTemplate(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_template.test", "name", name),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_template.test", "priority", "42"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_template.test", "index_patterns.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_template.test", "composed_of.0", name+"-component"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_template.test", "template.0.alias.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_template.test", "template.0.settings", `{"index":{"number_of_shards":"3"}}`),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_component_template.test", "name", name+"-component"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_component_template.test", "template.0.mappings", `{"properties":{"field1":{"type":"keyword"}}}`),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceIndexTemplate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_component_template" "test" {
+  name = "%[1]s-component"
+
+  template {
+    mappings = jsonencode({
+      properties = {
+        field1 = { type = "keyword" }
+      }
+    })
+  }
+}
+
+resource "elasticstack_elasticsearch_index_template" "test" {
+  name = "%[1]s"
+
+  priority       = 42
+  index_patterns = ["%[1]s-logs-*"]
+  composed_of    = [elasticstack_elasticsearch_component_template.test.name]
+
+  template {
+    alias {
+      name = "%[1]s-alias"
+    }
+
+    settings = jsonencode({
+      number_of_shards = "3"
+    })
+  }
+}
+
+data "elasticstack_elasticsearch_component_template" "test" {
+  name = elasticstack_elasticsearch_component_template.test.name
+}
+
+data "elasticstack_elasticsearch_index_template" "test" {
+  name = elasticstack_elasticsearch_index_template.test.name
+}
+	`, name)
+}
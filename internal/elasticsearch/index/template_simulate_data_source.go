@@ -0,0 +1,151 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceTemplateSimulate() *schema.Resource {
+	simulateSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"index_name": {
+			Description:  "Name of the index to simulate the template of, the index doesn't need to exist. The template resolved from the index templates matching the name, with the highest priority, is returned.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			AtLeastOneOf: []string{"index_name", "name", "template"},
+		},
+		"name": {
+			Description:   "Name of the existing index template to simulate.",
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"index_name", "template"},
+		},
+		"template": {
+			Description:      "Definition of an index template to simulate as JSON, as it would be sent to the index template API. It is included in the simulation of `index_name` as if it existed.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+			ValidateFunc:     validation.StringIsJSON,
+		},
+		"settings": {
+			Description: "Resolved settings, as JSON.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"mappings": {
+			Description: "Resolved mappings, as JSON.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"alias": indexDataSourceAttributes()["alias"],
+		"overlapping": {
+			Description: "Other index templates matching the index, or whose index patterns overlap with the simulated template. Only the template with the highest priority is applied to an index.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "Name of the index template.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"index_patterns": {
+						Description: "Index patterns of the index template.",
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(simulateSchema)
+
+	return &schema.Resource{
+		Description: "Simulates the settings, mappings and aliases an index would get from the index templates, once the component templates are merged and the priorities resolved. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-simulate-index.html and https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-simulate-template.html",
+
+		ReadContext: dataSourceTemplateSimulateRead,
+
+		Schema: simulateSchema,
+	}
+}
+
+func dataSourceTemplateSimulateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	indexName := d.Get("index_name").(string)
+	name := d.Get("name").(string)
+	template := d.Get("template").(string)
+
+	var simulated *models.SimulatedIndexTemplate
+	resourceId := "_simulate"
+	switch {
+	case indexName != "":
+		simulated, diags = elasticsearch.SimulateIndexTemplate(ctx, client, indexName, template)
+		resourceId = indexName
+	case name != "":
+		simulated, diags = elasticsearch.SimulateTemplate(ctx, client, name, "")
+		resourceId = name
+	default:
+		simulated, diags = elasticsearch.SimulateTemplate(ctx, client, "", template)
+	}
+	if diags.HasError() {
+		return diags
+	}
+	id, diags := client.ID(ctx, resourceId)
+	if diags.HasError() {
+		return diags
+	}
+
+	settings, err := json.Marshal(simulated.Template.Settings)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("settings", string(settings)); err != nil {
+		return diag.FromErr(err)
+	}
+	mappings, err := json.Marshal(simulated.Template.Mappings)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("mappings", string(mappings)); err != nil {
+		return diag.FromErr(err)
+	}
+	aliases, diags := FlattenIndexAliases(simulated.Template.Aliases)
+	if diags.HasError() {
+		return diags
+	}
+	if err := d.Set("alias", aliases); err != nil {
+		return diag.FromErr(err)
+	}
+	overlapping := make([]interface{}, 0, len(simulated.Overlapping))
+	for _, o := range simulated.Overlapping {
+		overlapping = append(overlapping, map[string]interface{}{
+			"name":           o.Name,
+			"index_patterns": o.IndexPatterns,
+		})
+	}
+	if err := d.Set("overlapping", overlapping); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return diags
+}
@@ -0,0 +1,121 @@
+package index_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceTemplateSimulate(t *testing.T) {
+	name := sdkacctest.RandStringFromCharSet(16, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceTemplateSimulate(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_template_simulate.index", "mappings", `{"properties":{"field1":{"type":"keyword"},"field2":{"type":"text"}}}`),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_template_simulate.index", "settings", `{"index":{"number_of_replicas":"0","number_of_shards":"2"}}`),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_template_simulate.index", "alias.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_template_simulate.index", "alias.0.name", name+"-alias"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_template_simulate.index", "overlapping.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_template_simulate.index", "overlapping.0.name", name+"-low"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_template_simulate.template", "mappings", `{"properties":{"field1":{"type":"keyword"},"field2":{"type":"text"}}}`),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_template_simulate.template", "overlapping.0.name", name+"-low"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_template_simulate.definition", "mappings", `{"properties":{"field1":{"type":"keyword"},"field3":{"type":"long"}}}`),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_template_simulate.definition", "overlapping.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_index_template_simulate.definition", "overlapping.0.name", name+"-high"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceTemplateSimulate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_component_template" "mappings" {
+  name = "%[1]s-mappings"
+
+  template {
+    mappings = jsonencode({
+      properties = {
+        field1 = { type = "keyword" }
+      }
+    })
+    settings = jsonencode({
+      number_of_shards = "2"
+    })
+  }
+}
+
+resource "elasticstack_elasticsearch_index_template" "high" {
+  name = "%[1]s-high"
+
+  priority       = 100
+  index_patterns = ["%[1]s-*"]
+  composed_of    = [elasticstack_elasticsearch_component_template.mappings.name]
+
+  template {
+    alias {
+      name = "%[1]s-alias"
+    }
+
+    mappings = jsonencode({
+      properties = {
+        field2 = { type = "text" }
+      }
+    })
+    settings = jsonencode({
+      number_of_replicas = "0"
+    })
+  }
+}
+
+resource "elasticstack_elasticsearch_index_template" "low" {
+  name = "%[1]s-low"
+
+  priority       = 10
+  index_patterns = ["%[1]s-logs-*"]
+}
+
+data "elasticstack_elasticsearch_index_template_simulate" "index" {
+  index_name = "%[1]s-logs-000001"
+
+  depends_on = [
+    elasticstack_elasticsearch_index_template.high,
+    elasticstack_elasticsearch_index_template.low,
+  ]
+}
+
+data "elasticstack_elasticsearch_index_template_simulate" "template" {
+  name = elasticstack_elasticsearch_index_template.high.name
+
+  depends_on = [elasticstack_elasticsearch_index_template.low]
+}
+
+data "elasticstack_elasticsearch_index_template_simulate" "definition" {
+  template = jsonencode({
+    index_patterns = ["%[1]s-metrics"]
+    composed_of    = [elasticstack_elasticsearch_component_template.mappings.name]
+    template = {
+      mappings = {
+        properties = {
+          field3 = { type = "long" }
+        }
+      }
+    }
+  })
+
+  depends_on = [elasticstack_elasticsearch_index_template.high]
+}
+	`, name)
+}
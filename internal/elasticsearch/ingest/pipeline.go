@@ -76,7 +76,7 @@ func ResourceIngestPipeline() *schema.Resource {
 		DeleteContext: resourceIngestPipelineTemplateDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStateContext,
 		},
 
 		Schema: pipelineSchema,
@@ -2,6 +2,7 @@ package ingest_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
@@ -35,6 +36,20 @@ func TestAccResourceIngestPipeline(t *testing.T) {
 					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "processors.#", "1"),
 				),
 			},
+			{
+				Config:            testAccResourceIngestPipelineUpdate(pipelineName),
+				ResourceName:      "elasticstack_elasticsearch_ingest_pipeline.test_pipeline",
+				ImportState:       true,
+				ImportStateId:     pipelineName,
+				ImportStateVerify: true,
+			},
+			{
+				Config:        testAccResourceIngestPipelineUpdate(pipelineName),
+				ResourceName:  "elasticstack_elasticsearch_ingest_pipeline.test_pipeline",
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("hbYAAVQ2Q5eMdCMkA55hdw/%s", pipelineName),
+				ExpectError:   regexp.MustCompile(`Cluster UUID mismatch`),
+			},
 		},
 	})
 }
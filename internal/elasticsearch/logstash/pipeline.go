@@ -181,7 +181,7 @@ func ResourceLogstashPipeline() *schema.Resource {
 		DeleteContext: resourceLogstashPipelineDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStateContext,
 		},
 
 		Schema: logstashPipelineSchema,
@@ -3,20 +3,27 @@ package security
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-var APIKeyMinVersion = version.Must(version.NewVersion("8.0.0")) // Enabled in 8.0
+const apiKeyResourceName = "elasticstack_elasticsearch_security_api_key"
+
+// apiKeyUpdateMinVersion is the first version supporting the update of the role descriptors and metadata of an API key.
+var apiKeyUpdateMinVersion = version.Must(version.NewVersion("8.4.0"))
 
 func ResourceApiKey() *schema.Resource {
 	apikeySchema := map[string]*schema.Schema{
@@ -36,30 +43,65 @@ func ResourceApiKey() *schema.Resource {
 			),
 		},
 		"role_descriptors": {
-			Description:      "Role descriptors for this API key.",
+			Description:      "Role descriptors for this API key. Updated in place since Elasticsearch 8.4, the API key is recreated on older versions.",
 			Type:             schema.TypeString,
 			Optional:         true,
-			ForceNew:         true,
 			ValidateFunc:     validation.StringIsJSON,
 			DiffSuppressFunc: utils.DiffJsonSuppress,
 		},
 		"expiration": {
-			Description: "Expiration time for the API key. By default, API keys never expire.",
-			Type:        schema.TypeString,
-			Optional:    true,
-			ForceNew:    true,
+			Description:      "Expiration time for the API key. By default, API keys never expire.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			DiffSuppressFunc: utils.DiffElasticDurationSuppress,
 		},
 		"expiration_timestamp": {
 			Description: "Expiration time in milliseconds for the API key. By default, API keys never expire.",
 			Type:        schema.TypeInt,
 			Computed:    true,
 		},
+		"creation_timestamp": {
+			Description: "Creation time in milliseconds of the API key.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"rotation": {
+			Description: "Rotates the API key: a new API key is created, and the previous one is kept valid during the `overlap` period so that its consumers can be moved to the new one. The `id` of the resource changes to the one of the new API key.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"rotate_after": {
+						Description:  "Rotates the API key once it is older than the duration, e.g. `2160h` for 90 days. The age of the API key is checked when planning.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: utils.StringIsDuration,
+					},
+					"keepers": {
+						Description: "Arbitrary map of values, the API key is rotated when any of them changes.",
+						Type:        schema.TypeMap,
+						Optional:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"overlap": {
+						Description:  "How long the previous API key remains valid after a rotation. It is invalidated by the first apply once the overlap has passed.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "0s",
+						ValidateFunc: utils.StringIsDuration,
+					},
+				},
+			},
+		},
 		"metadata": {
-			Description:      "Arbitrary metadata that you want to associate with the API key.",
+			Description:      "Arbitrary metadata that you want to associate with the API key. Updated in place since Elasticsearch 8.4, the API key is recreated on older versions.",
 			Type:             schema.TypeString,
 			Optional:         true,
 			Computed:         true,
-			ForceNew:         true,
 			ValidateFunc:     validation.StringIsJSON,
 			DiffSuppressFunc: utils.DiffJsonSuppress,
 		},
@@ -75,6 +117,28 @@ func ResourceApiKey() *schema.Resource {
 			Sensitive:   true,
 			Computed:    true,
 		},
+		"previous_id": {
+			Description: "Identifier of the previous API key, during the overlap period following a rotation.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"previous_api_key": {
+			Description: "Previous API key, during the overlap period following a rotation.",
+			Type:        schema.TypeString,
+			Sensitive:   true,
+			Computed:    true,
+		},
+		"previous_encoded": {
+			Description: "Base64-encoded credentials of the previous API key, during the overlap period following a rotation.",
+			Type:        schema.TypeString,
+			Sensitive:   true,
+			Computed:    true,
+		},
+		"previous_invalidation_timestamp": {
+			Description: "Time in milliseconds after which the previous API key is invalidated, by the next apply.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
 	}
 
 	utils.AddConnectionSchema(apikeySchema)
@@ -87,6 +151,16 @@ func ResourceApiKey() *schema.Resource {
 		ReadContext:   resourceSecurityApiKeyRead,
 		DeleteContext: resourceSecurityApiKeyDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSecurityApiKeyImport,
+		},
+
+		CustomizeDiff: customdiff.All(
+			versionutils.CheckCapabilities(apiKeyResourceName),
+			resourceSecurityApiKeyUpdateDiff,
+			resourceSecurityApiKeyRotationDiff,
+		),
+
 		Schema: apikeySchema,
 	}
 }
@@ -97,6 +171,18 @@ func resourceSecurityApiKeyCreate(ctx context.Context, d *schema.ResourceData, m
 		return diags
 	}
 
+	if diags := createApiKey(ctx, d, client); diags.HasError() {
+		return diags
+	}
+	if diags := setPreviousApiKey(d, "", "", "", 0); diags.HasError() {
+		return diags
+	}
+
+	return resourceSecurityApiKeyRead(ctx, d, meta)
+}
+
+// createApiKey creates an API key from the configuration and sets it as the current one.
+func createApiKey(ctx context.Context, d *schema.ResourceData, client *clients.ApiClient) diag.Diagnostics {
 	nameId := d.Get("name").(string)
 
 	var apikey models.ApiKey
@@ -133,15 +219,11 @@ func resourceSecurityApiKeyCreate(ctx context.Context, d *schema.ResourceData, m
 		return diags
 	}
 
-	if putResponse.Key != "" {
-		if err := d.Set("api_key", putResponse.Key); err != nil {
-			return diag.FromErr(err)
-		}
+	if err := d.Set("api_key", putResponse.Key); err != nil {
+		return diag.FromErr(err)
 	}
-	if putResponse.EncodedKey != "" {
-		if err := d.Set("encoded", putResponse.EncodedKey); err != nil {
-			return diag.FromErr(err)
-		}
+	if err := d.Set("encoded", putResponse.EncodedKey); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("expiration_timestamp", putResponse.Expiration); err != nil {
 		return diag.FromErr(err)
@@ -152,17 +234,249 @@ func resourceSecurityApiKeyCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	d.SetId(id.String())
-	return resourceSecurityApiKeyRead(ctx, d, meta)
+	return nil
+}
+
+func setPreviousApiKey(d *schema.ResourceData, id, apiKey, encoded string, invalidationTimestamp int64) diag.Diagnostics {
+	if err := d.Set("previous_id", id); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("previous_api_key", apiKey); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("previous_encoded", encoded); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("previous_invalidation_timestamp", invalidationTimestamp); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// resourceSecurityApiKeyUpdateDiff recreates the API key when its role descriptors or metadata change, and the
+// Elasticsearch server doesn't support updating them in place.
+func resourceSecurityApiKeyUpdateDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	var changed []string
+	for _, key := range []string{"role_descriptors", "metadata"} {
+		if d.HasChange(key) {
+			changed = append(changed, key)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	client, diags := clients.NewApiClientFromDiff(d, meta)
+	if diags.HasError() {
+		return fmt.Errorf("failed to get the Elasticsearch client: %v", diags)
+	}
+	serverVersion, diags := client.ServerVersion(ctx)
+	if diags.HasError() {
+		return fmt.Errorf("failed to get the Elasticsearch version: %v", diags)
+	}
+	if serverVersion.GreaterThanOrEqual(apiKeyUpdateMinVersion) {
+		return nil
+	}
+	for _, key := range changed {
+		if err := d.ForceNew(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourceSecurityApiKeyRotationDiff plans the rotation of the API key when its keepers change or it gets older
+// than rotate_after, and the invalidation of the previous API key once the overlap has passed.
+func resourceSecurityApiKeyRotationDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if apiKeyRotationDue(d) {
+		for _, key := range apiKeyRotatedAttributes {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	previousId := d.Get("previous_id").(string)
+	invalidationTimestamp := int64(d.Get("previous_invalidation_timestamp").(int))
+	if previousId != "" && time.Now().UnixMilli() >= invalidationTimestamp {
+		for _, key := range apiKeyPreviousAttributes {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// apiKeyPreviousAttributes are the attributes of the previous API key, unknown in the plan of its invalidation.
+var apiKeyPreviousAttributes = []string{
+	"previous_id",
+	"previous_api_key",
+	"previous_encoded",
+	"previous_invalidation_timestamp",
+}
+
+// apiKeyRotatedAttributes are the attributes changed by a rotation, creation_timestamp being unknown in the plan
+// marks the rotation for the update. The id isn't part of them since an unknown id forces the replacement of the
+// resource, it's changed to the one of the new API key by the update.
+var apiKeyRotatedAttributes = append([]string{
+	"api_key",
+	"encoded",
+	"expiration_timestamp",
+	"creation_timestamp",
+}, apiKeyPreviousAttributes...)
+
+func apiKeyRotationDue(d *schema.ResourceDiff) bool {
+	oldRotation, newRotation := d.GetChange("rotation")
+	if len(newRotation.([]interface{})) == 0 {
+		return false
+	}
+	// adding or removing the rotation block doesn't rotate the API key by itself
+	if len(oldRotation.([]interface{})) > 0 && d.HasChange("rotation.0.keepers") {
+		return true
+	}
+
+	rotateAfter, err := time.ParseDuration(d.Get("rotation.0.rotate_after").(string))
+	if err != nil {
+		return false
+	}
+	creation := int64(d.Get("creation_timestamp").(int))
+	return creation > 0 && time.Now().UnixMilli() >= creation+rotateAfter.Milliseconds()
 }
 
 func resourceSecurityApiKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	diags := diag.Diagnostics{diag.Diagnostic{
-		Severity: diag.Error,
-		Summary:  `Cannot update API Key`,
-		Detail:   `update not currently supported.`,
-	}}
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
 
-	return diags
+	// the creation timestamp is only unknown in the plan of a rotation
+	if !d.GetRawPlan().GetAttr("creation_timestamp").IsKnown() {
+		return rotateApiKey(ctx, d, meta, client, compId.ResourceId)
+	}
+
+	// the previous API key is only unknown in the plan of its invalidation
+	if previousId := d.Get("previous_id").(string); previousId != "" && !d.GetRawPlan().GetAttr("previous_id").IsKnown() {
+		if diags := elasticsearch.DeleteApiKey(client, previousId); diags.HasError() {
+			return diags
+		}
+		if diags := setPreviousApiKey(d, "", "", "", 0); diags.HasError() {
+			return diags
+		}
+	}
+
+	if !d.HasChanges("role_descriptors", "metadata") {
+		return resourceSecurityApiKeyRead(ctx, d, meta)
+	}
+
+	var update models.ApiKeyUpdate
+	if d.HasChange("role_descriptors") {
+		// the API key gets the privileges of its owner when the role descriptors are removed
+		role_descriptors := map[string]models.Role{}
+		if v, ok := d.GetOk("role_descriptors"); ok {
+			if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&role_descriptors); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		update.RolesDescriptors = &role_descriptors
+	}
+
+	if d.HasChange("metadata") {
+		metadata := make(map[string]interface{})
+		if v, ok := d.GetOk("metadata"); ok {
+			if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&metadata); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		update.Metadata = &metadata
+	}
+
+	if diags := elasticsearch.UpdateApiKey(ctx, client, compId.ResourceId, update); diags.HasError() {
+		return diags
+	}
+
+	return resourceSecurityApiKeyRead(ctx, d, meta)
+}
+
+// rotateApiKey replaces the current API key with a new one. The current API key becomes the previous one until
+// the overlap passes, an API key still in a previous overlap is invalidated right away.
+func rotateApiKey(ctx context.Context, d *schema.ResourceData, meta interface{}, client *clients.ApiClient, currentId string) diag.Diagnostics {
+	if previousId := d.Get("previous_id").(string); previousId != "" {
+		if diags := elasticsearch.DeleteApiKey(client, previousId); diags.HasError() {
+			return diags
+		}
+	}
+
+	overlap, err := time.ParseDuration(d.Get("rotation.0.overlap").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	currentApiKey := d.Get("api_key").(string)
+	currentEncoded := d.Get("encoded").(string)
+
+	if diags := createApiKey(ctx, d, client); diags.HasError() {
+		return diags
+	}
+
+	if overlap <= 0 {
+		if diags := elasticsearch.DeleteApiKey(client, currentId); diags.HasError() {
+			return diags
+		}
+		if diags := setPreviousApiKey(d, "", "", "", 0); diags.HasError() {
+			return diags
+		}
+	} else {
+		invalidationTimestamp := time.Now().Add(overlap).UnixMilli()
+		if diags := setPreviousApiKey(d, currentId, currentApiKey, currentEncoded, invalidationTimestamp); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceSecurityApiKeyRead(ctx, d, meta)
+}
+
+// setImportedApiKeyExpiration sets the expiration of an imported API key, which isn't returned by Elasticsearch, to the
+// duration between its creation and expiration, e.g. `86400000ms` for an API key expiring after a day. An equivalent
+// configured expiration doesn't replace the API key, which would invalidate the imported credentials.
+func setImportedApiKeyExpiration(d *schema.ResourceData, apikey *models.ApiKeyResponse) error {
+	if d.Get("expiration").(string) != "" || apikey.Expiration == 0 {
+		return nil
+	}
+	return d.Set("expiration", fmt.Sprintf("%dms", apikey.Expiration-apikey.Creation))
+}
+
+func resourceSecurityApiKeyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unable to create the API client: %v", diags)
+	}
+	compId, diags := client.ImportCompositeId(ctx, d.Id())
+	if diags.HasError() {
+		return nil, fmt.Errorf("unable to import the API key: %v", diags)
+	}
+
+	apikey, diags := elasticsearch.GetApiKey(client, compId.ResourceId)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unable to import the API key: %v", diags)
+	}
+	if apikey.Invalidated {
+		return nil, fmt.Errorf(`the API key "%s" has been invalidated and cannot be imported`, compId.ResourceId)
+	}
+
+	d.SetId(compId.String())
+	return []*schema.ResourceData{d}, nil
 }
 
 func resourceSecurityApiKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -175,6 +489,8 @@ func resourceSecurityApiKeyRead(ctx context.Context, d *schema.ResourceData, met
 		return diags
 	}
 	id := compId.ResourceId
+	// the name is required, it's only missing from the state of a freshly imported key
+	imported := d.Get("name").(string) == ""
 
 	apikey, diags := elasticsearch.GetApiKey(client, id)
 	if apikey == nil && diags == nil {
@@ -197,13 +513,24 @@ func resourceSecurityApiKeyRead(ctx context.Context, d *schema.ResourceData, met
 	if err := d.Set("expiration_timestamp", apikey.Expiration); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set("creation_timestamp", apikey.Creation); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setImportedApiKeyExpiration(d, apikey); err != nil {
+		return diag.FromErr(err)
+	}
 
 	if apikey.RolesDescriptors != nil {
-		rolesDescriptors, err := json.Marshal(apikey.RolesDescriptors)
-		if err != nil {
-			return diag.FromErr(err)
+		// an API key without role descriptors, having the privileges of its owner, returns an empty object
+		rolesDescriptors := ""
+		if len(apikey.RolesDescriptors) > 0 {
+			rolesDescriptorsBytes, err := json.Marshal(apikey.RolesDescriptors)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			rolesDescriptors = string(rolesDescriptorsBytes)
 		}
-		if err := d.Set("role_descriptors", string(rolesDescriptors)); err != nil {
+		if err := d.Set("role_descriptors", rolesDescriptors); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -212,6 +539,17 @@ func resourceSecurityApiKeyRead(ctx context.Context, d *schema.ResourceData, met
 		return diag.FromErr(err)
 	}
 
+	if imported {
+		if diags := setPreviousApiKey(d, "", "", "", 0); diags.HasError() {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "The API key secret is not available",
+			Detail:   fmt.Sprintf(`Elasticsearch only returns the secret of an API key when creating it, the "api_key" and "encoded" attributes of the imported API key "%s" are left empty.`, id),
+		})
+	}
+
 	return diags
 }
 
@@ -228,6 +566,11 @@ func resourceSecurityApiKeyDelete(ctx context.Context, d *schema.ResourceData, m
 	if diags := elasticsearch.DeleteApiKey(client, compId.ResourceId); diags.HasError() {
 		return diags
 	}
+	if previousId := d.Get("previous_id").(string); previousId != "" {
+		if diags := elasticsearch.DeleteApiKey(client, previousId); diags.HasError() {
+			return diags
+		}
+	}
 
 	d.SetId("")
 	return diags
@@ -1,17 +1,19 @@
 package security_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
-	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/security"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
+	"github.com/hashicorp/go-version"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -27,7 +29,7 @@ func TestAccResourceSecuritApiKey(t *testing.T) {
 		ProtoV5ProviderFactories: acctest.Providers,
 		Steps: []resource.TestStep{
 			{
-				SkipFunc: versionutils.CheckIfVersionIsUnsupported(security.APIKeyMinVersion),
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(versionutils.APIKeysEnabledVersion),
 				Config:   testAccResourceSecuritApiKeyCreate(apiKeyName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_api_key.test", "name", apiKeyName),
@@ -60,10 +62,342 @@ func TestAccResourceSecuritApiKey(t *testing.T) {
 					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_security_api_key.test", "encoded"),
 				),
 			},
+			{
+				SkipFunc:          versionutils.CheckIfVersionIsUnsupported(versionutils.APIKeysEnabledVersion),
+				ResourceName:      "elasticstack_elasticsearch_security_api_key.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the secrets are only returned on creation, role descriptors only by recent versions
+				ImportStateVerifyIgnore: []string{"api_key", "encoded", "expiration", "role_descriptors"},
+			},
+		},
+	})
+}
+
+func TestAccResourceSecurityApiKeyImportExpiration(t *testing.T) {
+	apiKeyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+	var importId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceSecurityApiKeyDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(versionutils.APIKeysEnabledVersion),
+				// the API key is created outside of Terraform to be imported
+				PreConfig: func() {
+					importId = createApiKey(t, apiKeyName)
+				},
+				Config:             testAccResourceSecurityApiKeyExpiration(apiKeyName, "1d"),
+				ResourceName:       "elasticstack_elasticsearch_security_api_key.test",
+				ImportState:        true,
+				ImportStateIdFunc:  func(*terraform.State) (string, error) { return importId, nil },
+				ImportStatePersist: true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if expiration := states[0].Attributes["expiration"]; expiration != "86400000ms" {
+						return fmt.Errorf("expected the expiration of the imported API key to be 86400000ms, got %s", expiration)
+					}
+					return nil
+				},
+			},
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(versionutils.APIKeysEnabledVersion),
+				// the equivalent configured expiration doesn't replace the imported API key
+				Config:   testAccResourceSecurityApiKeyExpiration(apiKeyName, "24h"),
+				PlanOnly: true,
+			},
+			{
+				SkipFunc:           versionutils.CheckIfVersionIsUnsupported(versionutils.APIKeysEnabledVersion),
+				Config:             testAccResourceSecurityApiKeyExpiration(apiKeyName, "2d"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccResourceSecurityApiKeyExpiration(apiKeyName, expiration string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_api_key" "test" {
+  name = "%s"
+
+  role_descriptors = jsonencode({
+    role-a = {
+      cluster = ["all"]
+      indices = [{
+        names                    = ["index-a*"]
+        privileges               = ["read"]
+        allow_restricted_indices = false
+      }]
+    }
+  })
+
+  expiration = "%s"
+}
+	`, apiKeyName, expiration)
+}
+
+// createApiKey creates an API key expiring in a day and returns its import identifier.
+func createApiKey(t *testing.T, apiKeyName string) string {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowRestrictedIndices := false
+	apiKey, diags := elasticsearch.PutApiKey(client, &models.ApiKey{
+		Name: apiKeyName,
+		RolesDescriptors: map[string]models.Role{
+			"role-a": {
+				Cluster: []string{"all"},
+				Indices: []models.IndexPerms{{
+					Names:                  []string{"index-a*"},
+					Privileges:             []string{"read"},
+					AllowRestrictedIndices: &allowRestrictedIndices,
+				}},
+			},
+		},
+		Expiration: "1d",
+	})
+	if diags.HasError() {
+		t.Fatalf("Unable to create the API key %v", diags)
+	}
+	id, diags := client.ID(context.Background(), apiKey.Id)
+	if diags.HasError() {
+		t.Fatalf("Unable to get the API key identifier %v", diags)
+	}
+	return id.String()
+}
+
+var apiKeyUpdateVersion = version.Must(version.NewVersion("8.4.0"))
+
+func TestAccResourceSecurityApiKeyUpdate(t *testing.T) {
+	apiKeyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+	var apiKeyId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceSecurityApiKeyDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(apiKeyUpdateVersion),
+				Config:   testAccResourceSecurityApiKeyUpdate(apiKeyName, "read", "team-a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_api_key.test", "metadata", `{"team":"team-a"}`),
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_api_key.test", "id", func(value string) error {
+						apiKeyId = value
+						return nil
+					}),
+				),
+			},
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(apiKeyUpdateVersion),
+				Config:   testAccResourceSecurityApiKeyUpdate(apiKeyName, "write", "team-b"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_api_key.test", "metadata", `{"team":"team-b"}`),
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_api_key.test", "id", func(value string) error {
+						if value != apiKeyId {
+							return fmt.Errorf("expected the API key %s to be updated in place, got %s", apiKeyId, value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceSecurityApiKeyUpdate(apiKeyName, privilege, team string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_api_key" "test" {
+  name = "%s"
+
+  role_descriptors = jsonencode({
+    role-a = {
+      cluster = ["monitor"]
+      indices = [{
+        names                    = ["index-a*"]
+        privileges               = ["%s"]
+        allow_restricted_indices = false
+      }]
+    }
+  })
+
+  metadata = jsonencode({
+    team = "%s"
+  })
+}
+	`, apiKeyName, privilege, team)
+}
+
+func TestAccResourceSecurityApiKeyRotation(t *testing.T) {
+	apiKeyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+	var firstApiKeyId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceSecurityApiKeyDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(versionutils.APIKeysEnabledVersion),
+				Config:   testAccResourceSecurityApiKeyRotation(apiKeyName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_security_api_key.test", "creation_timestamp"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_api_key.test", "previous_id", ""),
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_api_key.test", "id", func(value string) error {
+						compId, _ := clients.CompositeIdFromStr(value)
+						firstApiKeyId = compId.ResourceId
+						return nil
+					}),
+				),
+			},
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(versionutils.APIKeysEnabledVersion),
+				Config:   testAccResourceSecurityApiKeyRotation(apiKeyName, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_security_api_key.test", "previous_api_key"),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_security_api_key.test", "previous_encoded"),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_security_api_key.test", "previous_invalidation_timestamp"),
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_api_key.test", "previous_id", func(value string) error {
+						if value != firstApiKeyId {
+							return fmt.Errorf("expected the previous API key to be %s, got %s", firstApiKeyId, value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_api_key.test", "id", func(value string) error {
+						if compId, _ := clients.CompositeIdFromStr(value); compId.ResourceId == firstApiKeyId {
+							return fmt.Errorf("expected the API key %s to be rotated", firstApiKeyId)
+						}
+						return nil
+					}),
+					checkApiKeyInvalidated(&firstApiKeyId, false),
+				),
+			},
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(versionutils.APIKeysEnabledVersion),
+				// wait for the overlap to pass
+				PreConfig: func() { time.Sleep(apiKeyRotationOverlap) },
+				Config:    testAccResourceSecurityApiKeyRotation(apiKeyName, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_api_key.test", "previous_id", ""),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_api_key.test", "previous_api_key", ""),
+					checkApiKeyInvalidated(&firstApiKeyId, true),
+				),
+			},
 		},
 	})
 }
 
+const apiKeyRotationOverlap = 15 * time.Second
+
+func testAccResourceSecurityApiKeyRotation(apiKeyName, version string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_api_key" "test" {
+  name = "%s"
+
+  rotation {
+    keepers = {
+      version = "%s"
+    }
+    overlap = "%s"
+  }
+}
+	`, apiKeyName, version, apiKeyRotationOverlap)
+}
+
+func checkApiKeyInvalidated(id *string, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := clients.NewAcceptanceTestingClient()
+		if err != nil {
+			return err
+		}
+		apiKey, diags := elasticsearch.GetApiKey(client, *id)
+		if diags.HasError() {
+			return fmt.Errorf("Unable to get API key %v", diags)
+		}
+		if apiKey.Invalidated != expected {
+			return fmt.Errorf("expected the API key %s invalidated to be %t, got %t", *id, expected, apiKey.Invalidated)
+		}
+		return nil
+	}
+}
+
+func TestAccResourceSecurityApiKeyRemoteIndices(t *testing.T) {
+	apiKeyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceSecurityApiKeyDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(remoteIndicesVersion),
+				Config:   testAccResourceSecurityApiKeyRemoteIndices(apiKeyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_api_key.test", "role_descriptors", func(testValue string) error {
+						var testRoleDescriptor map[string]models.Role
+						if err := json.Unmarshal([]byte(testValue), &testRoleDescriptor); err != nil {
+							return err
+						}
+
+						allowRestrictedIndices := false
+						expectedRemoteIndices := []models.RemoteIndexPerms{{
+							IndexPerms: models.IndexPerms{
+								Names:                  []string{"logs-*"},
+								Privileges:             []string{"read"},
+								AllowRestrictedIndices: &allowRestrictedIndices,
+							},
+							Clusters: []string{"remote-*"},
+						}}
+
+						if !reflect.DeepEqual(testRoleDescriptor["role-a"].RemoteIndices, expectedRemoteIndices) {
+							return fmt.Errorf("%v doesn't match %v", testRoleDescriptor["role-a"].RemoteIndices, expectedRemoteIndices)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceSecurityApiKeyRemoteIndices(apiKeyName string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_api_key" "test" {
+  name = "%s"
+
+  role_descriptors = jsonencode({
+    role-a = {
+      remote_indices = [{
+        clusters                 = ["remote-*"]
+        names                    = ["logs-*"]
+        privileges               = ["read"]
+        allow_restricted_indices = false
+      }]
+    }
+  })
+}
+	`, apiKeyName)
+}
+
 func testAccResourceSecuritApiKeyCreate(apiKeyName string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
@@ -96,7 +430,7 @@ func checkResourceSecurityApiKeyDestroy(s *terraform.State) error {
 	}
 
 	for _, rs := range s.RootModule().Resources {
-		if rs.Type != "elasticstack_elasticsearch_security_api_key" {
+		if rs.Type != "elasticstack_elasticsearch_security_api_key" && rs.Type != "elasticstack_elasticsearch_security_cross_cluster_api_key" {
 			continue
 		}
 		compId, _ := clients.CompositeIdFromStr(rs.Primary.ID)
@@ -0,0 +1,174 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceApplicationPrivilege() *schema.Resource {
+	privilegeSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"application": {
+			Description:  "The name of the application the privilege belongs to.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-z][^/\s]*$`), "must begin with a lowercase letter and must not contain whitespaces or slashes"),
+		},
+		"name": {
+			Description:  "The name of the privilege.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-z][a-zA-Z0-9_.-]*$`), "must begin with a lowercase letter and contain only letters, digits, underscores (_), dashes (-) and dots (.)"),
+		},
+		"actions": {
+			Description: "The actions granted by the privilege. Action names must contain one of `/`, `*` or `:`.",
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"metadata": {
+			Description:      "Optional meta-data. Within the metadata object, keys that begin with `_` are reserved for system usage.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          "{}",
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+	}
+
+	utils.AddConnectionSchema(privilegeSchema)
+
+	return &schema.Resource{
+		Description: "Adds or updates application privileges. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-put-privileges.html",
+
+		CreateContext: resourceSecurityApplicationPrivilegePut,
+		UpdateContext: resourceSecurityApplicationPrivilegePut,
+		ReadContext:   resourceSecurityApplicationPrivilegeRead,
+		DeleteContext: resourceSecurityApplicationPrivilegeDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: clients.ImportStateContext,
+		},
+
+		Schema: privilegeSchema,
+	}
+}
+
+func resourceSecurityApplicationPrivilegePut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	application := d.Get("application").(string)
+	name := d.Get("name").(string)
+	id, diags := client.ID(ctx, application+"/"+name)
+	if diags.HasError() {
+		return diags
+	}
+
+	privilege := models.ApplicationPrivilege{
+		Application: application,
+		Name:        name,
+		Actions:     utils.ExpandStringSet(d.Get("actions").(*schema.Set)),
+		Metadata:    json.RawMessage(d.Get("metadata").(string)),
+	}
+	if diags := elasticsearch.PutApplicationPrivilege(ctx, client, &privilege); diags.HasError() {
+		return diags
+	}
+	d.SetId(id.String())
+
+	return resourceSecurityApplicationPrivilegeRead(ctx, d, meta)
+}
+
+func resourceSecurityApplicationPrivilegeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	application, name, diags := applicationPrivilegeFromId(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	privilege, diags := elasticsearch.GetApplicationPrivilege(ctx, client, application, name)
+	if privilege == nil && diags == nil {
+		tflog.Warn(ctx, fmt.Sprintf(`Application privilege "%s" of "%s" not found, removing from state`, name, application))
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	metadata := "{}"
+	if privilege.Metadata != nil {
+		metadataBytes, err := json.Marshal(privilege.Metadata)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		metadata = string(metadataBytes)
+	}
+
+	if err := d.Set("application", privilege.Application); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", privilege.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("actions", privilege.Actions); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("metadata", metadata); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceSecurityApplicationPrivilegeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	application, name, diags := applicationPrivilegeFromId(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	if diags := elasticsearch.DeleteApplicationPrivilege(ctx, client, application, name); diags.HasError() {
+		return diags
+	}
+	return nil
+}
+
+// applicationPrivilegeFromId returns the application and name of the privilege from the <cluster_uuid>/<application>/<name> id.
+func applicationPrivilegeFromId(id string) (string, string, diag.Diagnostics) {
+	resourceID, diags := clients.ResourceIDFromStr(id)
+	if diags.HasError() {
+		return "", "", diags
+	}
+	application, name, found := strings.Cut(resourceID, "/")
+	if !found {
+		return "", "", diag.Errorf("the application privilege identifier [%s] must be formatted as <application>/<name>", resourceID)
+	}
+	return application, name, nil
+}
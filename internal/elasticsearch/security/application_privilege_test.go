@@ -0,0 +1,142 @@
+package security_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceSecurityApplicationPrivilege(t *testing.T) {
+	application := "app" + sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             resource.ComposeTestCheckFunc(checkResourceSecurityApplicationPrivilegeDestroy, checkResourceSecurityRoleDestroy),
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSecurityApplicationPrivilegeCreate(application),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_application_privilege.test", "application", application),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_application_privilege.test", "name", "read"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_application_privilege.test", "actions.#", "1"),
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_application_privilege.test", "actions.*", "data:read/*"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_application_privilege.test", "metadata", "{}"),
+				),
+			},
+			{
+				Config: testAccResourceSecurityApplicationPrivilegeUpdate(application),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_application_privilege.test", "actions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_application_privilege.test", "actions.*", "data:read/*"),
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_application_privilege.test", "actions.*", "action:login"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_application_privilege.test", "metadata", `{"description":"Read access"}`),
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_role.test", "applications.*.privileges.*", "read"),
+				),
+			},
+			{
+				// changes made outside of Terraform are detected
+				PreConfig:          func() { updateApplicationPrivilege(t, application, "read", []string{"data:write/*"}) },
+				Config:             testAccResourceSecurityApplicationPrivilegeUpdate(application),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccResourceSecurityApplicationPrivilegeUpdate(application),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_application_privilege.test", "actions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_application_privilege.test", "actions.*", "action:login"),
+				),
+			},
+			{
+				ResourceName:      "elasticstack_elasticsearch_security_application_privilege.test",
+				ImportState:       true,
+				ImportStateId:     application + "/read",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceSecurityApplicationPrivilegeCreate(application string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_application_privilege" "test" {
+  application = "%s"
+  name        = "read"
+  actions     = ["data:read/*"]
+}
+	`, application)
+}
+
+func testAccResourceSecurityApplicationPrivilegeUpdate(application string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_application_privilege" "test" {
+  application = "%s"
+  name        = "read"
+  actions     = ["data:read/*", "action:login"]
+  metadata    = jsonencode({ description = "Read access" })
+}
+
+resource "elasticstack_elasticsearch_security_role" "test" {
+  name = "%s"
+
+  applications {
+    application = elasticstack_elasticsearch_security_application_privilege.test.application
+    privileges  = [elasticstack_elasticsearch_security_application_privilege.test.name]
+    resources   = ["*"]
+  }
+}
+	`, application, application)
+}
+
+func updateApplicationPrivilege(t *testing.T, application, name string, actions []string) {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	privilege := &models.ApplicationPrivilege{Application: application, Name: name, Actions: actions}
+	if diags := elasticsearch.PutApplicationPrivilege(context.Background(), client, privilege); diags.HasError() {
+		t.Fatalf("Unable to update the application privilege: %v", diags)
+	}
+}
+
+func checkResourceSecurityApplicationPrivilegeDestroy(s *terraform.State) error {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_security_application_privilege" {
+			continue
+		}
+		compId, _ := clients.CompositeIdFromStr(rs.Primary.ID)
+		application, name, _ := strings.Cut(compId.ResourceId, "/")
+
+		privilege, diags := elasticsearch.GetApplicationPrivilege(context.Background(), client, application, name)
+		if diags.HasError() {
+			return fmt.Errorf("Unable to get application privilege %v", diags)
+		}
+		if privilege != nil {
+			return fmt.Errorf("Application privilege (%s) still exists", compId.ResourceId)
+		}
+	}
+	return nil
+}
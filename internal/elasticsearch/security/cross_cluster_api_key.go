@@ -0,0 +1,395 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const crossClusterApiKeyResourceName = "elasticstack_elasticsearch_security_cross_cluster_api_key"
+
+func ResourceCrossClusterApiKey() *schema.Resource {
+	apikeySchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "Specifies the name for this API key.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			ValidateFunc: validation.All(
+				validation.StringLenBetween(1, 1024),
+				validation.StringMatch(regexp.MustCompile(`^([[:graph:]]| )+$`), "must contain alphanumeric characters (a-z, A-Z, 0-9), spaces, punctuation, and printable symbols in the Basic Latin (ASCII) block. Leading or trailing whitespace is not allowed"),
+			),
+		},
+		"access": {
+			Description: "The indices the remote cluster is granted access to, to search them or to replicate them with cross-cluster replication.",
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"search": {
+						Description:  "A list of indices permissions entries for cross-cluster search.",
+						Type:         schema.TypeSet,
+						Optional:     true,
+						AtLeastOneOf: []string{"access.0.search", "access.0.replication"},
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"names": {
+									Description: "A list of indices (or index name patterns) to which the permissions in this entry apply.",
+									Type:        schema.TypeSet,
+									Required:    true,
+									Elem: &schema.Schema{
+										Type: schema.TypeString,
+									},
+								},
+								"query": {
+									Description:      "A search query that defines the documents the remote cluster has read access to.",
+									Type:             schema.TypeString,
+									Optional:         true,
+									ValidateFunc:     validation.StringIsJSON,
+									DiffSuppressFunc: utils.DiffJsonSuppress,
+								},
+								"field_security": {
+									Description: "The document fields that the remote cluster has read access to.",
+									Type:        schema.TypeList,
+									Optional:    true,
+									MaxItems:    1,
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"grant": {
+												Description: "List of the fields to grant the access to.",
+												Type:        schema.TypeSet,
+												Optional:    true,
+												Elem: &schema.Schema{
+													Type: schema.TypeString,
+												},
+											},
+											"except": {
+												Description: "List of the fields to which the grants will not be applied.",
+												Type:        schema.TypeSet,
+												Optional:    true,
+												Elem: &schema.Schema{
+													Type: schema.TypeString,
+												},
+											},
+										},
+									},
+								},
+								"allow_restricted_indices": {
+									Description: "Include matching restricted indices in names parameter. Usage is strongly discouraged as it can grant unrestricted operations on critical data, make the entire system unstable or leak sensitive information.",
+									Type:        schema.TypeBool,
+									Optional:    true,
+								},
+							},
+						},
+					},
+					"replication": {
+						Description:  "A list of indices permissions entries for cross-cluster replication.",
+						Type:         schema.TypeSet,
+						Optional:     true,
+						AtLeastOneOf: []string{"access.0.search", "access.0.replication"},
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"names": {
+									Description: "A list of indices (or index name patterns) to which the permissions in this entry apply.",
+									Type:        schema.TypeSet,
+									Required:    true,
+									Elem: &schema.Schema{
+										Type: schema.TypeString,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"expiration": {
+			Description:      "Expiration time for the API key. By default, API keys never expire.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			DiffSuppressFunc: utils.DiffElasticDurationSuppress,
+		},
+		"expiration_timestamp": {
+			Description: "Expiration time in milliseconds for the API key. By default, API keys never expire.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"metadata": {
+			Description:      "Arbitrary metadata that you want to associate with the API key.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+		"api_key": {
+			Description: "Generated API Key.",
+			Type:        schema.TypeString,
+			Sensitive:   true,
+			Computed:    true,
+		},
+		"encoded": {
+			Description: "API key credentials which is the Base64-encoding of the UTF-8 representation of the id and api_key joined by a colon (:), to be configured on the remote cluster.",
+			Type:        schema.TypeString,
+			Sensitive:   true,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(apikeySchema)
+
+	return &schema.Resource{
+		Description: "Creates a cross-cluster API key, granting a remote cluster access to this cluster with API key based security. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-create-cross-cluster-api-key.html",
+
+		CreateContext: resourceSecurityCrossClusterApiKeyCreate,
+		UpdateContext: resourceSecurityCrossClusterApiKeyUpdate,
+		ReadContext:   resourceSecurityCrossClusterApiKeyRead,
+		DeleteContext: resourceSecurityCrossClusterApiKeyDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSecurityApiKeyImport,
+		},
+
+		CustomizeDiff: versionutils.CheckCapabilities(crossClusterApiKeyResourceName),
+
+		Schema: apikeySchema,
+	}
+}
+
+func resourceSecurityCrossClusterApiKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	apikey := models.CrossClusterApiKey{
+		Name:       d.Get("name").(string),
+		Access:     expandCrossClusterApiKeyAccess(d.Get("access").([]interface{})),
+		Expiration: d.Get("expiration").(string),
+	}
+	if v, ok := d.GetOk("metadata"); ok {
+		metadata := make(map[string]interface{})
+		if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&metadata); err != nil {
+			return diag.FromErr(err)
+		}
+		apikey.Metadata = &metadata
+	}
+
+	putResponse, diags := elasticsearch.CreateCrossClusterApiKey(ctx, client, &apikey)
+	if diags.HasError() {
+		return diags
+	}
+
+	id, diags := client.ID(ctx, putResponse.Id)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("api_key", putResponse.Key); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("encoded", putResponse.EncodedKey); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("expiration_timestamp", putResponse.Expiration); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return resourceSecurityCrossClusterApiKeyRead(ctx, d, meta)
+}
+
+func resourceSecurityCrossClusterApiKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	var update models.CrossClusterApiKey
+	if d.HasChange("access") {
+		update.Access = expandCrossClusterApiKeyAccess(d.Get("access").([]interface{}))
+	}
+	if d.HasChange("metadata") {
+		metadata := make(map[string]interface{})
+		if v, ok := d.GetOk("metadata"); ok {
+			if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&metadata); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		update.Metadata = &metadata
+	}
+
+	if diags := elasticsearch.UpdateCrossClusterApiKey(ctx, client, compId.ResourceId, &update); diags.HasError() {
+		return diags
+	}
+
+	return resourceSecurityCrossClusterApiKeyRead(ctx, d, meta)
+}
+
+func resourceSecurityCrossClusterApiKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	id := compId.ResourceId
+	// the name is required, it's only missing from the state of a freshly imported key
+	imported := d.Get("name").(string) == ""
+
+	apikey, diags := elasticsearch.GetApiKey(client, id)
+	if apikey == nil && diags == nil {
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+	if apikey.Type != "cross_cluster" {
+		return diag.Errorf(`the API key "%s" isn't a cross-cluster API key`, id)
+	}
+
+	if err := d.Set("name", apikey.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("expiration_timestamp", apikey.Expiration); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setImportedApiKeyExpiration(d, apikey); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("access", flattenCrossClusterApiKeyAccess(apikey.Access)); err != nil {
+		return diag.FromErr(err)
+	}
+	metadata, err := json.Marshal(apikey.Metadata)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("metadata", string(metadata)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if imported {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "The API key secret is not available",
+			Detail:   fmt.Sprintf(`Elasticsearch only returns the secret of an API key when creating it, the "api_key" and "encoded" attributes of the imported API key "%s" are left empty.`, id),
+		})
+	}
+
+	return diags
+}
+
+func resourceSecurityCrossClusterApiKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	if diags := elasticsearch.DeleteApiKey(client, compId.ResourceId); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func expandCrossClusterApiKeyAccess(definedAccess []interface{}) *models.CrossClusterApiKeyAccess {
+	var access models.CrossClusterApiKeyAccess
+	if len(definedAccess) == 0 || definedAccess[0] == nil {
+		return &access
+	}
+	a := definedAccess[0].(map[string]interface{})
+
+	for _, s := range a["search"].(*schema.Set).List() {
+		search := s.(map[string]interface{})
+		searchAccess := models.CrossClusterSearchAccess{
+			Names: utils.ExpandStringSet(search["names"].(*schema.Set)),
+		}
+		if query := search["query"].(string); query != "" {
+			searchAccess.Query = &query
+		}
+		if fieldSec := search["field_security"].([]interface{}); len(fieldSec) > 0 && fieldSec[0] != nil {
+			definedFieldSec := fieldSec[0].(map[string]interface{})
+			searchAccess.FieldSecurity = &models.FieldSecurity{
+				Grant:  utils.ExpandStringSet(definedFieldSec["grant"].(*schema.Set)),
+				Except: utils.ExpandStringSet(definedFieldSec["except"].(*schema.Set)),
+			}
+		}
+		// restricted indices are left out unless they're explicitly allowed
+		if allowRestrictedIndices := search["allow_restricted_indices"].(bool); allowRestrictedIndices {
+			searchAccess.AllowRestrictedIndices = &allowRestrictedIndices
+		}
+		access.Search = append(access.Search, searchAccess)
+	}
+
+	for _, r := range a["replication"].(*schema.Set).List() {
+		replication := r.(map[string]interface{})
+		access.Replication = append(access.Replication, models.CrossClusterReplicationAccess{
+			Names: utils.ExpandStringSet(replication["names"].(*schema.Set)),
+		})
+	}
+	return &access
+}
+
+func flattenCrossClusterApiKeyAccess(access *models.CrossClusterApiKeyAccess) []interface{} {
+	if access == nil {
+		return []interface{}{}
+	}
+
+	search := make([]interface{}, len(access.Search))
+	for i, s := range access.Search {
+		oa := map[string]interface{}{
+			"names":                    s.Names,
+			"query":                    s.Query,
+			"allow_restricted_indices": s.AllowRestrictedIndices,
+		}
+		if s.FieldSecurity != nil {
+			oa["field_security"] = []interface{}{map[string]interface{}{
+				"grant":  s.FieldSecurity.Grant,
+				"except": s.FieldSecurity.Except,
+			}}
+		}
+		search[i] = oa
+	}
+
+	replication := make([]interface{}, len(access.Replication))
+	for i, r := range access.Replication {
+		replication[i] = map[string]interface{}{
+			"names": r.Names,
+		}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"search":      search,
+		"replication": replication,
+	}}
+}
@@ -0,0 +1,122 @@
+package security_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceSecurityCrossClusterApiKey(t *testing.T) {
+	apiKeyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+	var apiKeyId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceSecurityApiKeyDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(remoteIndicesVersion),
+				Config:   testAccResourceSecurityCrossClusterApiKeyCreate(apiKeyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "name", apiKeyName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "access.0.search.#", "1"),
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "access.0.search.*.names.*", "logs-*"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "access.0.replication.#", "0"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "metadata", `{"env":"test"}`),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "encoded"),
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "id", func(value string) error {
+						apiKeyId = value
+						return nil
+					}),
+				),
+			},
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(remoteIndicesVersion),
+				Config:   testAccResourceSecurityCrossClusterApiKeyUpdate(apiKeyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "access.0.search.#", "1"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "access.0.search.0.query", `{"term":{"team":"ops"}}`),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "access.0.replication.#", "1"),
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "access.0.replication.*.names.*", "archive-*"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "metadata", `{"env":"prod"}`),
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "id", func(value string) error {
+						if value != apiKeyId {
+							return fmt.Errorf("expected the API key %s to be updated in place, got %s", apiKeyId, value)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				SkipFunc:          versionutils.CheckIfVersionIsUnsupported(remoteIndicesVersion),
+				ResourceName:      "elasticstack_elasticsearch_security_cross_cluster_api_key.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the secrets are only returned on creation
+				ImportStateVerifyIgnore: []string{"api_key", "encoded", "expiration"},
+			},
+		},
+	})
+}
+
+func testAccResourceSecurityCrossClusterApiKeyCreate(apiKeyName string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_cross_cluster_api_key" "test" {
+  name = "%s"
+
+  access {
+    search {
+      names = ["logs-*", "metrics-*"]
+    }
+  }
+
+  expiration = "30d"
+
+  metadata = jsonencode({
+    env = "test"
+  })
+}
+	`, apiKeyName)
+}
+
+func testAccResourceSecurityCrossClusterApiKeyUpdate(apiKeyName string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_cross_cluster_api_key" "test" {
+  name = "%s"
+
+  access {
+    search {
+      names = ["logs-*", "metrics-*"]
+      query = jsonencode({ term = { team = "ops" } })
+
+      field_security {
+        grant = ["*"]
+      }
+    }
+
+    replication {
+      names = ["archive-*"]
+    }
+  }
+
+  expiration = "30d"
+
+  metadata = jsonencode({
+    env = "prod"
+  })
+}
+	`, apiKeyName)
+}
@@ -10,12 +10,15 @@ import (
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const roleResourceName = "elasticstack_elasticsearch_security_role"
+
 func ResourceRole() *schema.Resource {
 	roleSchema := map[string]*schema.Schema{
 		"id": {
@@ -137,6 +140,77 @@ func ResourceRole() *schema.Resource {
 				},
 			},
 		},
+		"remote_indices": {
+			Description: "A list of remote indices permissions entries, granting access to the indices of remote clusters connected with API key based security.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"clusters": {
+						Description: "A list of remote cluster aliases (or alias patterns) to which the permissions in this entry apply.",
+						Type:        schema.TypeSet,
+						Required:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"field_security": {
+						Description: "The document fields that the owners of the role have read access to.",
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"grant": {
+									Description: "List of the fields to grant the access to.",
+									Type:        schema.TypeSet,
+									Optional:    true,
+									Elem: &schema.Schema{
+										Type: schema.TypeString,
+									},
+								},
+								"except": {
+									Description: "List of the fields to which the grants will not be applied.",
+									Type:        schema.TypeSet,
+									Optional:    true,
+									Elem: &schema.Schema{
+										Type: schema.TypeString,
+									},
+								},
+							},
+						},
+					},
+					"names": {
+						Description: "A list of indices (or index name patterns) of the remote clusters to which the permissions in this entry apply.",
+						Type:        schema.TypeSet,
+						Required:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"privileges": {
+						Description: "The index level privileges that the owners of the role have on the specified indices.",
+						Type:        schema.TypeSet,
+						Required:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"query": {
+						Description:      "A search query that defines the documents the owners of the role have read access to.",
+						Type:             schema.TypeString,
+						ValidateFunc:     validation.StringIsJSON,
+						DiffSuppressFunc: utils.DiffJsonSuppress,
+						Optional:         true,
+					},
+					"allow_restricted_indices": {
+						Description: "Include matching restricted indices in names parameter. Usage is strongly discouraged as it can grant unrestricted operations on critical data, make the entire system unstable or leak sensitive information.",
+						Type:        schema.TypeBool,
+						Optional:    true,
+					},
+				},
+			},
+		},
 		"metadata": {
 			Description:      "Optional meta-data.",
 			Type:             schema.TypeString,
@@ -166,9 +240,11 @@ func ResourceRole() *schema.Resource {
 		DeleteContext: resourceSecurityRoleDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStateContext,
 		},
 
+		CustomizeDiff: versionutils.CheckCapabilities(roleResourceName),
+
 		Schema: roleSchema,
 	}
 }
@@ -233,57 +309,22 @@ func resourceSecurityRolePut(ctx context.Context, d *schema.ResourceData, meta i
 		definedIndices := v.(*schema.Set)
 		indices := make([]models.IndexPerms, definedIndices.Len())
 		for i, idx := range definedIndices.List() {
-			index := idx.(map[string]interface{})
-
-			definedNames := index["names"].(*schema.Set)
-			names := make([]string, definedNames.Len())
-			for i, name := range definedNames.List() {
-				names[i] = name.(string)
-			}
-			definedPrivs := index["privileges"].(*schema.Set)
-			privs := make([]string, definedPrivs.Len())
-			for i, pr := range definedPrivs.List() {
-				privs[i] = pr.(string)
-			}
-
-			newIndex := models.IndexPerms{
-				Names:      names,
-				Privileges: privs,
-			}
+			indices[i] = expandIndexPerms(idx.(map[string]interface{}))
+		}
+		role.Indices = indices
+	}
 
-			if query := index["query"].(string); query != "" {
-				newIndex.Query = &query
-			}
-			if fieldSec := index["field_security"].([]interface{}); len(fieldSec) > 0 {
-				fieldSecurity := models.FieldSecurity{}
-				// there must be only 1 entry
-				definedFieldSec := fieldSec[0].(map[string]interface{})
-
-				// grants
-				if gr := definedFieldSec["grant"].(*schema.Set); gr != nil {
-					grants := make([]string, gr.Len())
-					for i, grant := range gr.List() {
-						grants[i] = grant.(string)
-					}
-					fieldSecurity.Grant = grants
-				}
-				// except
-				if exp := definedFieldSec["except"].(*schema.Set); exp != nil {
-					excepts := make([]string, exp.Len())
-					for i, except := range exp.List() {
-						excepts[i] = except.(string)
-					}
-					fieldSecurity.Except = excepts
-				}
-				newIndex.FieldSecurity = &fieldSecurity
+	if v, ok := d.GetOk("remote_indices"); ok {
+		definedIndices := v.(*schema.Set)
+		remoteIndices := make([]models.RemoteIndexPerms, definedIndices.Len())
+		for i, idx := range definedIndices.List() {
+			index := idx.(map[string]interface{})
+			remoteIndices[i] = models.RemoteIndexPerms{
+				IndexPerms: expandIndexPerms(index),
+				Clusters:   utils.ExpandStringSet(index["clusters"].(*schema.Set)),
 			}
-
-			allowRestrictedIndices := index["allow_restricted_indices"].(bool)
-			newIndex.AllowRestrictedIndices = &allowRestrictedIndices
-
-			indices[i] = newIndex
 		}
-		role.Indices = indices
+		role.RemoteIndices = remoteIndices
 	}
 
 	if v, ok := d.GetOk("metadata"); ok {
@@ -311,6 +352,56 @@ func resourceSecurityRolePut(ctx context.Context, d *schema.ResourceData, meta i
 	return resourceSecurityRoleRead(ctx, d, meta)
 }
 
+func expandIndexPerms(index map[string]interface{}) models.IndexPerms {
+	definedNames := index["names"].(*schema.Set)
+	names := make([]string, definedNames.Len())
+	for i, name := range definedNames.List() {
+		names[i] = name.(string)
+	}
+	definedPrivs := index["privileges"].(*schema.Set)
+	privs := make([]string, definedPrivs.Len())
+	for i, pr := range definedPrivs.List() {
+		privs[i] = pr.(string)
+	}
+
+	newIndex := models.IndexPerms{
+		Names:      names,
+		Privileges: privs,
+	}
+
+	if query := index["query"].(string); query != "" {
+		newIndex.Query = &query
+	}
+	if fieldSec := index["field_security"].([]interface{}); len(fieldSec) > 0 {
+		fieldSecurity := models.FieldSecurity{}
+		// there must be only 1 entry
+		definedFieldSec := fieldSec[0].(map[string]interface{})
+
+		// grants
+		if gr := definedFieldSec["grant"].(*schema.Set); gr != nil {
+			grants := make([]string, gr.Len())
+			for i, grant := range gr.List() {
+				grants[i] = grant.(string)
+			}
+			fieldSecurity.Grant = grants
+		}
+		// except
+		if exp := definedFieldSec["except"].(*schema.Set); exp != nil {
+			excepts := make([]string, exp.Len())
+			for i, except := range exp.List() {
+				excepts[i] = except.(string)
+			}
+			fieldSecurity.Except = excepts
+		}
+		newIndex.FieldSecurity = &fieldSecurity
+	}
+
+	allowRestrictedIndices := index["allow_restricted_indices"].(bool)
+	newIndex.AllowRestrictedIndices = &allowRestrictedIndices
+
+	return newIndex
+}
+
 func resourceSecurityRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, diags := clients.NewApiClient(d, meta)
 	if diags.HasError() {
@@ -363,6 +454,11 @@ func resourceSecurityRoleRead(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
+	remoteIndices := flattenRemoteIndicesData(role.RemoteIndices)
+	if err := d.Set("remote_indices", remoteIndices); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if role.Metadata != nil {
 		metadata, err := json.Marshal(role.Metadata)
 		if err != nil {
@@ -400,25 +496,39 @@ func flattenIndicesData(indices *[]models.IndexPerms) []interface{} {
 		oindx := make([]interface{}, len(*indices))
 
 		for i, index := range *indices {
-			oi := make(map[string]interface{})
-			oi["names"] = index.Names
-			oi["privileges"] = index.Privileges
-			oi["query"] = index.Query
-			oi["allow_restricted_indices"] = index.AllowRestrictedIndices
-
-			if index.FieldSecurity != nil {
-				fsec := make(map[string]interface{})
-				fsec["grant"] = index.FieldSecurity.Grant
-				fsec["except"] = index.FieldSecurity.Except
-				oi["field_security"] = []interface{}{fsec}
-			}
-			oindx[i] = oi
+			oindx[i] = flattenIndexPerms(index)
 		}
 		return oindx
 	}
 	return make([]interface{}, 0)
 }
 
+func flattenRemoteIndicesData(remoteIndices []models.RemoteIndexPerms) []interface{} {
+	oindx := make([]interface{}, len(remoteIndices))
+	for i, index := range remoteIndices {
+		oi := flattenIndexPerms(index.IndexPerms)
+		oi["clusters"] = index.Clusters
+		oindx[i] = oi
+	}
+	return oindx
+}
+
+func flattenIndexPerms(index models.IndexPerms) map[string]interface{} {
+	oi := make(map[string]interface{})
+	oi["names"] = index.Names
+	oi["privileges"] = index.Privileges
+	oi["query"] = index.Query
+	oi["allow_restricted_indices"] = index.AllowRestrictedIndices
+
+	if index.FieldSecurity != nil {
+		fsec := make(map[string]interface{})
+		fsec["grant"] = index.FieldSecurity.Grant
+		fsec["except"] = index.FieldSecurity.Except
+		oi["field_security"] = []interface{}{fsec}
+	}
+	return oi
+}
+
 func resourceSecurityRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, diags := clients.NewApiClient(d, meta)
 	if diags.HasError() {
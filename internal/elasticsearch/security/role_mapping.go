@@ -76,7 +76,7 @@ func ResourceRoleMapping() *schema.Resource {
 		DeleteContext: resourceSecurityRoleMappingDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStateContext,
 		},
 
 		Schema: roleMappingSchema,
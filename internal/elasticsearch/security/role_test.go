@@ -6,6 +6,8 @@ import (
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
+	"github.com/hashicorp/go-version"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -49,6 +51,74 @@ func TestAccResourceSecurityRole(t *testing.T) {
 	})
 }
 
+var remoteIndicesVersion = version.Must(version.NewVersion("8.10.0"))
+
+func TestAccResourceSecurityRoleRemoteIndices(t *testing.T) {
+	roleName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceSecurityRoleDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(remoteIndicesVersion),
+				Config:   testAccResourceSecurityRoleRemoteIndices(roleName, "read"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_role.test", "remote_indices.#", "1"),
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_role.test", "remote_indices.*.clusters.*", "remote-*"),
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_role.test", "remote_indices.*.names.*", "logs-*"),
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_role.test", "remote_indices.*.privileges.*", "read"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_role.test", "remote_indices.0.field_security.0.grant.#", "2"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_role.test", "remote_indices.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.elasticstack_elasticsearch_security_role.test", "remote_indices.*.clusters.*", "remote-*"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_role.test", "remote_indices.0.query", `{"match":{"team":"ops"}}`),
+				),
+			},
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(remoteIndicesVersion),
+				Config:   testAccResourceSecurityRoleRemoteIndices(roleName, "view_index_metadata"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_role.test", "remote_indices.*.privileges.*", "view_index_metadata"),
+				),
+			},
+			{
+				SkipFunc:          versionutils.CheckIfVersionIsUnsupported(remoteIndicesVersion),
+				ResourceName:      "elasticstack_elasticsearch_security_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceSecurityRoleRemoteIndices(roleName, privilege string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_role" "test" {
+  name = "%s"
+
+  remote_indices {
+    clusters   = ["remote-*"]
+    names      = ["logs-*"]
+    privileges = ["%s"]
+    query      = jsonencode({ match = { team = "ops" } })
+
+    field_security {
+      grant = ["@timestamp", "message"]
+    }
+  }
+}
+
+data "elasticstack_elasticsearch_security_role" "test" {
+  name = elasticstack_elasticsearch_security_role.test.name
+}
+	`, roleName, privilege)
+}
+
 func testAccResourceSecurityRoleCreate(roleName string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
@@ -0,0 +1,145 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceServiceAccounts() *schema.Resource {
+	serviceAccountsSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"namespace": {
+			Description: "Name of the namespace to list the service accounts of, e.g. `elastic`. All the service accounts are listed by default.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"service": {
+			Description:  "Name of the service to get the service account of, e.g. `fleet-server`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			RequiredWith: []string{"namespace"},
+		},
+		"service_accounts": {
+			Description: "The service accounts.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "Name of the service account, as `<namespace>/<service>`.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"role_descriptor": {
+						Description: "The privileges of the service account, as JSON.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"tokens": {
+						Description: "Names of the service account tokens stored in the security index, like the ones created with `elasticstack_elasticsearch_security_service_token`.",
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"file_tokens": {
+						Description: "Names of the service account tokens configured in the `service_tokens` file of the nodes.",
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(serviceAccountsSchema)
+
+	return &schema.Resource{
+		Description: "Retrieves the service accounts and the names of their tokens. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-get-service-accounts.html",
+		ReadContext: dataSourceSecurityServiceAccountsRead,
+		Schema:      serviceAccountsSchema,
+	}
+}
+
+func dataSourceSecurityServiceAccountsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	namespace := d.Get("namespace").(string)
+	service := d.Get("service").(string)
+
+	serviceAccounts, diags := elasticsearch.GetServiceAccounts(ctx, client, namespace, service)
+	if diags.HasError() {
+		return diags
+	}
+
+	names := make([]string, 0, len(serviceAccounts))
+	for name := range serviceAccounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	accounts := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		roleDescriptor, err := json.Marshal(serviceAccounts[name].RoleDescriptor)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		accountNamespace, accountService := splitServiceAccount(name)
+		credentials, diags := elasticsearch.GetServiceCredentials(ctx, client, accountNamespace, accountService)
+		if diags.HasError() {
+			return diags
+		}
+		tokens, fileTokens := []string{}, []string{}
+		if credentials != nil {
+			tokens = sortedKeys(credentials.Tokens)
+			fileTokens = sortedKeys(credentials.NodesCredentials.FileTokens)
+		}
+		accounts = append(accounts, map[string]interface{}{
+			"name":            name,
+			"role_descriptor": string(roleDescriptor),
+			"tokens":          tokens,
+			"file_tokens":     fileTokens,
+		})
+	}
+	if err := d.Set("service_accounts", accounts); err != nil {
+		return diag.FromErr(err)
+	}
+
+	resourceId := "_all"
+	if namespace != "" {
+		resourceId = strings.Trim(namespace+"/"+service, "/")
+	}
+	id, diags := client.ID(ctx, resourceId)
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(id.String())
+	return diags
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
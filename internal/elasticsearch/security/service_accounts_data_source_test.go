@@ -0,0 +1,53 @@
+package security_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSecurityServiceAccounts(t *testing.T) {
+	tokenName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceSecurityServiceTokenDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(serviceTokenVersion),
+				Config:   testAccDataSourceSecurityServiceAccounts(tokenName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_service_accounts.test", "service_accounts.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_service_accounts.test", "service_accounts.0.name", "elastic/fleet-server"),
+					resource.TestCheckResourceAttrSet("data.elasticstack_elasticsearch_security_service_accounts.test", "service_accounts.0.role_descriptor"),
+					resource.TestCheckTypeSetElemAttr("data.elasticstack_elasticsearch_security_service_accounts.test", "service_accounts.0.tokens.*", tokenName),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceSecurityServiceAccounts(tokenName string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_service_token" "test" {
+  service_account = "elastic/fleet-server"
+  name            = "%s"
+}
+
+data "elasticstack_elasticsearch_security_service_accounts" "test" {
+  namespace = "elastic"
+  service   = "fleet-server"
+
+  depends_on = [elasticstack_elasticsearch_security_service_token.test]
+}
+	`, tokenName)
+}
@@ -0,0 +1,164 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const serviceTokenResourceName = "elasticstack_elasticsearch_security_service_token"
+
+func ResourceServiceToken() *schema.Resource {
+	tokenSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"service_account": {
+			Description:  "The service account the token is created for, as `<namespace>/<service>`, e.g. `elastic/fleet-server`.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[^/]+/[^/]+$`), "must be formatted as <namespace>/<service>"),
+		},
+		"name": {
+			Description: "The name of the token, unique for the service account.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			ValidateFunc: validation.All(
+				validation.StringLenBetween(1, 256),
+				validation.StringMatch(regexp.MustCompile(`^[a-zA-Z0-9-][a-zA-Z0-9_-]*$`), "must contain alphanumeric characters (a-z, A-Z, 0-9), dashes (-) and underscores (_), and cannot begin with an underscore"),
+			),
+		},
+		"value": {
+			Description: "The bearer token, to authenticate as the service account.",
+			Type:        schema.TypeString,
+			Sensitive:   true,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(tokenSchema)
+
+	return &schema.Resource{
+		Description: "Creates a service account token, for services such as Fleet Server or Kibana to authenticate without a user. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-create-service-token.html",
+
+		CreateContext: resourceSecurityServiceTokenCreate,
+		UpdateContext: resourceSecurityServiceTokenUpdate,
+		ReadContext:   resourceSecurityServiceTokenRead,
+		DeleteContext: resourceSecurityServiceTokenDelete,
+
+		CustomizeDiff: versionutils.CheckCapabilities(serviceTokenResourceName),
+
+		Schema: tokenSchema,
+	}
+}
+
+func resourceSecurityServiceTokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	serviceAccount := d.Get("service_account").(string)
+	name := d.Get("name").(string)
+	namespace, service := splitServiceAccount(serviceAccount)
+
+	id, diags := client.ID(ctx, serviceAccount+"/"+name)
+	if diags.HasError() {
+		return diags
+	}
+
+	token, diags := elasticsearch.CreateServiceToken(ctx, client, namespace, service, name)
+	if diags.HasError() {
+		return diags
+	}
+	if err := d.Set("value", token.Value); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return resourceSecurityServiceTokenRead(ctx, d, meta)
+}
+
+func resourceSecurityServiceTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	namespace, service, name, diags := serviceTokenFromId(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	credentials, diags := elasticsearch.GetServiceCredentials(ctx, client, namespace, service)
+	if diags.HasError() {
+		return diags
+	}
+	if credentials == nil || credentials.Tokens[name] == nil {
+		tflog.Warn(ctx, fmt.Sprintf(`Service token "%s" of "%s/%s" not found, removing from state`, name, namespace, service))
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("service_account", namespace+"/"+service); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceSecurityServiceTokenUpdate only handles changes to the connection, every other attribute forces a new token.
+func resourceSecurityServiceTokenUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceSecurityServiceTokenRead(ctx, d, meta)
+}
+
+func resourceSecurityServiceTokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	namespace, service, name, diags := serviceTokenFromId(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	if diags := elasticsearch.DeleteServiceToken(ctx, client, namespace, service, name); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func splitServiceAccount(serviceAccount string) (string, string) {
+	namespace, service, _ := strings.Cut(serviceAccount, "/")
+	return namespace, service
+}
+
+// serviceTokenFromId returns the namespace, service and name of the token from the <cluster_uuid>/<namespace>/<service>/<name> id.
+func serviceTokenFromId(id string) (string, string, string, diag.Diagnostics) {
+	compId, diags := clients.CompositeIdFromStr(id)
+	if diags.HasError() {
+		return "", "", "", diags
+	}
+	parts := strings.Split(compId.ResourceId, "/")
+	if len(parts) != 3 {
+		return "", "", "", diag.Errorf("the service token identifier [%s] must be formatted as <namespace>/<service>/<name>", compId.ResourceId)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
@@ -0,0 +1,78 @@
+package security_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
+	"github.com/hashicorp/go-version"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var serviceTokenVersion = version.Must(version.NewVersion("7.13.0"))
+
+func TestAccResourceSecurityServiceToken(t *testing.T) {
+	tokenName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceSecurityServiceTokenDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(serviceTokenVersion),
+				Config:   testAccResourceSecurityServiceToken(tokenName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_service_token.test", "service_account", "elastic/fleet-server"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_service_token.test", "name", tokenName),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_security_service_token.test", "value"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceSecurityServiceToken(tokenName string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_service_token" "test" {
+  service_account = "elastic/fleet-server"
+  name            = "%s"
+}
+	`, tokenName)
+}
+
+func checkResourceSecurityServiceTokenDestroy(s *terraform.State) error {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_security_service_token" {
+			continue
+		}
+		compId, _ := clients.CompositeIdFromStr(rs.Primary.ID)
+		parts := strings.Split(compId.ResourceId, "/")
+
+		credentials, diags := elasticsearch.GetServiceCredentials(context.Background(), client, parts[0], parts[1])
+		if diags.HasError() {
+			return fmt.Errorf("Unable to get service account credentials %v", diags)
+		}
+
+		if credentials != nil && credentials.Tokens[parts[2]] != nil {
+			return fmt.Errorf("Service token (%s) still exists", compId.ResourceId)
+		}
+	}
+	return nil
+}
@@ -66,6 +66,10 @@ func ResourceSystemUser() *schema.Resource {
 		ReadContext:   resourceSecuritySystemUserRead,
 		DeleteContext: resourceSecuritySystemUserDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSecuritySystemUserImport,
+		},
+
 		Schema: userSchema,
 	}
 }
@@ -120,6 +124,28 @@ func resourceSecuritySystemUserPut(ctx context.Context, d *schema.ResourceData,
 	return resourceSecuritySystemUserRead(ctx, d, meta)
 }
 
+func resourceSecuritySystemUserImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unable to create the API client: %v", diags)
+	}
+	compId, diags := client.ImportCompositeId(ctx, d.Id())
+	if diags.HasError() {
+		return nil, fmt.Errorf("unable to import the system user: %v", diags)
+	}
+
+	user, diags := elasticsearch.GetUser(ctx, client, compId.ResourceId)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unable to import the system user: %v", diags)
+	}
+	if user == nil || !user.IsSystemUser() {
+		return nil, fmt.Errorf(`System user "%s" not found`, compId.ResourceId)
+	}
+
+	d.SetId(compId.String())
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceSecuritySystemUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, diags := clients.NewApiClient(d, meta)
 	if diags.HasError() {
@@ -27,6 +27,13 @@ func TestAccResourceSecuritySystemUser(t *testing.T) {
 					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_system_user.remote_monitoring_user", "enabled", "false"),
 				),
 			},
+			{
+				ResourceName:            "elasticstack_elasticsearch_security_system_user.remote_monitoring_user",
+				ImportState:             true,
+				ImportStateId:           "remote_monitoring_user",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
 		},
 	})
 }
@@ -40,6 +47,13 @@ func TestAccResourceSecuritySystemUserNotFound(t *testing.T) {
 				Config:      testAccResourceSecuritySystemUserNotFound,
 				ExpectError: regexp.MustCompile(`System user "not_system_user" not found`),
 			},
+			{
+				Config:        testAccResourceSecuritySystemUserNotFound,
+				ResourceName:  "elasticstack_elasticsearch_security_system_user.test",
+				ImportState:   true,
+				ImportStateId: "not_system_user",
+				ExpectError:   regexp.MustCompile(`System user "not_system_user" not found`),
+			},
 		},
 	})
 }
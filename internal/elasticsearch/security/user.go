@@ -98,7 +98,7 @@ func ResourceUser() *schema.Resource {
 		DeleteContext: resourceSecurityUserDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStateContext,
 		},
 
 		Schema: userSchema,
@@ -12,6 +12,7 @@ import (
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -19,28 +20,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-var settingsRequiredVersions map[string]*version.Version
-
-func init() {
-	settingsRequiredVersions = make(map[string]*version.Version)
-
-	// capabilities
-	settingsRequiredVersions["destination.pipeline"] = version.Must(version.NewVersion("7.3.0"))
-	settingsRequiredVersions["frequency"] = version.Must(version.NewVersion("7.3.0"))
-	settingsRequiredVersions["latest"] = version.Must(version.NewVersion("7.11.0"))
-	settingsRequiredVersions["retention_policy"] = version.Must(version.NewVersion("7.12.0"))
-	settingsRequiredVersions["source.runtime_mappings"] = version.Must(version.NewVersion("7.12.0"))
-	settingsRequiredVersions["metadata"] = version.Must(version.NewVersion("7.16.0"))
-
-	// settings
-	settingsRequiredVersions["docs_per_second"] = version.Must(version.NewVersion("7.8.0"))
-	settingsRequiredVersions["max_page_search_size"] = version.Must(version.NewVersion("7.8.0"))
-	settingsRequiredVersions["dates_as_epoch_millis"] = version.Must(version.NewVersion("7.11.0"))
-	settingsRequiredVersions["align_checkpoints"] = version.Must(version.NewVersion("7.15.0"))
-	settingsRequiredVersions["deduce_mappings"] = version.Must(version.NewVersion("8.1.0"))
-	settingsRequiredVersions["num_failure_retries"] = version.Must(version.NewVersion("8.4.0"))
-	settingsRequiredVersions["unattended"] = version.Must(version.NewVersion("8.5.0"))
-}
+const transformResourceName = "elasticstack_elasticsearch_transform"
 
 func ResourceTransform() *schema.Resource {
 	transformSchema := map[string]*schema.Schema{
@@ -290,8 +270,10 @@ func ResourceTransform() *schema.Resource {
 		DeleteContext: resourceTransformDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStateContext,
 		},
+
+		CustomizeDiff: versionutils.CheckCapabilities(transformResourceName),
 	}
 }
 
@@ -827,11 +809,10 @@ func flattenRetentionPolicy(retention *models.TransformRetentionPolicy) []interf
 }
 
 func isSettingAllowed(ctx context.Context, settingName string, serverVersion *version.Version) bool {
-	if minVersion, ok := settingsRequiredVersions[settingName]; ok {
-		if serverVersion.LessThan(minVersion) {
-			tflog.Warn(ctx, fmt.Sprintf("Setting [%s] not allowed for Elasticsearch server version %v; min required is %v", settingName, *serverVersion, *minVersion))
-			return false
-		}
+	if !versionutils.IsSupported(transformResourceName, settingName, serverVersion) {
+		minVersion := versionutils.MinVersion(transformResourceName, settingName)
+		tflog.Warn(ctx, fmt.Sprintf("Setting [%s] not allowed for Elasticsearch server version %v; min required is %v", settingName, *serverVersion, *minVersion))
+		return false
 	}
 
 	return true
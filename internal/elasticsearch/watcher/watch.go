@@ -90,7 +90,7 @@ func ResourceWatch() *schema.Resource {
 		DeleteContext: resourceWatchDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: clients.ImportStateContext,
 		},
 
 		Schema: watchSchema,
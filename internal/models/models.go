@@ -47,13 +47,14 @@ type UserPassword struct {
 }
 
 type Role struct {
-	Name         string                 `json:"-"`
-	Applications []Application          `json:"applications,omitempty"`
-	Global       map[string]interface{} `json:"global,omitempty"`
-	Cluster      []string               `json:"cluster,omitempty"`
-	Indices      []IndexPerms           `json:"indices,omitempty"`
-	Metadata     map[string]interface{} `json:"metadata,omitempty"`
-	RusAs        []string               `json:"run_as,omitempty"`
+	Name          string                 `json:"-"`
+	Applications  []Application          `json:"applications,omitempty"`
+	Global        map[string]interface{} `json:"global,omitempty"`
+	Cluster       []string               `json:"cluster,omitempty"`
+	Indices       []IndexPerms           `json:"indices,omitempty"`
+	RemoteIndices []RemoteIndexPerms     `json:"remote_indices,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	RusAs         []string               `json:"run_as,omitempty"`
 }
 
 type RoleMapping struct {
@@ -65,6 +66,13 @@ type RoleMapping struct {
 	Metadata      interface{}              `json:"metadata"`
 }
 
+type ApplicationPrivilege struct {
+	Application string      `json:"application"`
+	Name        string      `json:"name"`
+	Actions     []string    `json:"actions"`
+	Metadata    interface{} `json:"metadata"`
+}
+
 type ApiKey struct {
 	Name             string                 `json:"name"`
 	RolesDescriptors map[string]Role        `json:"role_descriptors,omitempty"`
@@ -72,14 +80,67 @@ type ApiKey struct {
 	Metadata         map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// ApiKeyUpdate holds the changes of an API key, the omitted fields are left unchanged.
+type ApiKeyUpdate struct {
+	RolesDescriptors *map[string]Role        `json:"role_descriptors,omitempty"`
+	Metadata         *map[string]interface{} `json:"metadata,omitempty"`
+}
+
 type ApiKeyResponse struct {
 	ApiKey
-	RolesDescriptors map[string]Role `json:"role_descriptors,omitempty"`
-	Expiration       int64           `json:"expiration,omitempty"`
-	Id               string          `json:"id,omitempty"`
-	Key              string          `json:"api_key,omitempty"`
-	EncodedKey       string          `json:"encoded,omitempty"`
-	Invalidated      bool            `json:"invalidated,omitempty"`
+	RolesDescriptors map[string]Role           `json:"role_descriptors,omitempty"`
+	Expiration       int64                     `json:"expiration,omitempty"`
+	Creation         int64                     `json:"creation,omitempty"`
+	Id               string                    `json:"id,omitempty"`
+	Key              string                    `json:"api_key,omitempty"`
+	EncodedKey       string                    `json:"encoded,omitempty"`
+	Invalidated      bool                      `json:"invalidated,omitempty"`
+	Type             string                    `json:"type,omitempty"`
+	Access           *CrossClusterApiKeyAccess `json:"access,omitempty"`
+}
+
+// CrossClusterApiKey is an API key granting a remote cluster access to this cluster, to search or replicate its indices.
+type CrossClusterApiKey struct {
+	Name       string                    `json:"name,omitempty"`
+	Access     *CrossClusterApiKeyAccess `json:"access,omitempty"`
+	Expiration string                    `json:"expiration,omitempty"`
+	Metadata   *map[string]interface{}   `json:"metadata,omitempty"`
+}
+
+type CrossClusterApiKeyAccess struct {
+	Search      []CrossClusterSearchAccess      `json:"search,omitempty"`
+	Replication []CrossClusterReplicationAccess `json:"replication,omitempty"`
+}
+
+type CrossClusterSearchAccess struct {
+	Names                  []string       `json:"names"`
+	Query                  *string        `json:"query,omitempty"`
+	FieldSecurity          *FieldSecurity `json:"field_security,omitempty"`
+	AllowRestrictedIndices *bool          `json:"allow_restricted_indices,omitempty"`
+}
+
+type CrossClusterReplicationAccess struct {
+	Names []string `json:"names"`
+}
+
+type ServiceAccount struct {
+	RoleDescriptor map[string]interface{} `json:"role_descriptor"`
+}
+
+type ServiceToken struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ServiceCredentials holds the tokens of a service account, the ones stored in the security index and the ones
+// configured in the service_tokens file of the nodes.
+type ServiceCredentials struct {
+	ServiceAccount   string                 `json:"service_account"`
+	Count            int                    `json:"count"`
+	Tokens           map[string]interface{} `json:"tokens"`
+	NodesCredentials struct {
+		FileTokens map[string]interface{} `json:"file_tokens"`
+	} `json:"nodes_credentials"`
 }
 
 type IndexPerms struct {
@@ -90,6 +151,10 @@ type IndexPerms struct {
 	AllowRestrictedIndices *bool          `json:"allow_restricted_indices,omitempty"`
 }
 
+type RemoteIndexPerms struct {
+	IndexPerms
+	Clusters []string `json:"clusters"`
+}
 type FieldSecurity struct {
 	Grant  []string `json:"grant,omitempty"`
 	Except []string `json:"except,omitempty"`
@@ -134,6 +199,18 @@ type IndexTemplateResponse struct {
 	IndexTemplate IndexTemplate `json:"index_template"`
 }
 
+// SimulatedIndexTemplate is the template resolved by the index template simulation APIs, with the templates
+// overlapping the simulated one.
+type SimulatedIndexTemplate struct {
+	Template    Template              `json:"template"`
+	Overlapping []OverlappingTemplate `json:"overlapping"`
+}
+
+type OverlappingTemplate struct {
+	Name          string   `json:"name"`
+	IndexPatterns []string `json:"index_patterns"`
+}
+
 type ComponentTemplate struct {
 	Name     string                 `json:"-"`
 	Meta     map[string]interface{} `json:"_meta,omitempty"`
@@ -207,6 +284,26 @@ type Index struct {
 	Settings map[string]interface{} `json:"settings,omitempty"`
 }
 
+// IndexStats holds the statistics of an index reported by the cat indices API, the numbers being strings.
+type IndexStats struct {
+	Health       string `json:"health"`
+	Status       string `json:"status"`
+	Index        string `json:"index"`
+	UUID         string `json:"uuid"`
+	DocsCount    string `json:"docs.count"`
+	StoreSize    string `json:"store.size"`
+	CreationDate string `json:"creation.date"`
+}
+
+// IndexShard holds a copy of a shard reported by the cat shards API.
+type IndexShard struct {
+	Index  string `json:"index"`
+	Shard  string `json:"shard"`
+	Prirep string `json:"prirep"`
+	State  string `json:"state"`
+	Node   string `json:"node"`
+}
+
 type PutIndexParams struct {
 	WaitForActiveShards string
 	MasterTimeout       time.Duration
@@ -224,6 +321,13 @@ type IndexAlias struct {
 	SearchRouting string                 `json:"search_routing,omitempty"`
 }
 
+// AliasAction is an action of the aliases API, either `add` or `remove`, applying the alias to the index.
+type AliasAction struct {
+	Type  string
+	Index string
+	Alias IndexAlias
+}
+
 type DataStream struct {
 	Name           string                 `json:"name"`
 	TimestampField TimestampField         `json:"timestamp_field"`
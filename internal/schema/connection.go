@@ -2,8 +2,10 @@ package schema
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func GetEsConnectionSchema(keyName string, isProviderConfiguration bool) *schema.Schema {
@@ -15,6 +17,10 @@ func GetEsConnectionSchema(keyName string, isProviderConfiguration bool) *schema
 	certDataPath := makePathRef(keyName, "cert_data")
 	keyFilePath := makePathRef(keyName, "key_file")
 	keyDataPath := makePathRef(keyName, "key_data")
+	apiKeyPath := makePathRef(keyName, "api_key")
+	bearerTokenPath := makePathRef(keyName, "bearer_token")
+	endpointsPath := makePathRef(keyName, "endpoints")
+	cloudIdPath := makePathRef(keyName, "cloud_id")
 
 	usernameRequiredWithValidation := []string{passwordPath}
 	passwordRequiredWithValidation := []string{usernamePath}
@@ -60,17 +66,33 @@ func GetEsConnectionSchema(keyName string, isProviderConfiguration bool) *schema
 					Optional:      true,
 					Sensitive:     true,
 					DefaultFunc:   withEnvDefault("ELASTICSEARCH_API_KEY", nil),
-					ConflictsWith: []string{usernamePath, passwordPath},
+					ConflictsWith: []string{usernamePath, passwordPath, bearerTokenPath},
+				},
+				"bearer_token": {
+					Description:   "Bearer token to use for authentication to Elasticsearch, e.g. a service account token.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					DefaultFunc:   withEnvDefault("ELASTICSEARCH_BEARER_TOKEN", nil),
+					ConflictsWith: []string{usernamePath, passwordPath, apiKeyPath},
 				},
 				"endpoints": {
-					Description: "A list of endpoints where the terraform provider will point to, this must include the http(s) schema and port number.",
-					Type:        schema.TypeList,
-					Optional:    true,
-					Sensitive:   true,
+					Description:   "A list of endpoints where the terraform provider will point to, this must include the http(s) schema and port number.",
+					Type:          schema.TypeList,
+					Optional:      true,
+					Sensitive:     true,
+					ConflictsWith: []string{cloudIdPath},
 					Elem: &schema.Schema{
 						Type: schema.TypeString,
 					},
 				},
+				"cloud_id": {
+					Description:   "Elastic Cloud ID of the deployment, used instead of `endpoints`. The Kibana endpoint is derived from it as well unless configured explicitly.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   withEnvDefault("ELASTICSEARCH_CLOUD_ID", nil),
+					ConflictsWith: []string{endpointsPath},
+				},
 				"insecure": {
 					Description: "Disable TLS certificate validation",
 					Type:        schema.TypeBool,
@@ -118,14 +140,87 @@ func GetEsConnectionSchema(keyName string, isProviderConfiguration bool) *schema
 					RequiredWith:  []string{certDataPath},
 					ConflictsWith: []string{certFilePath, keyFilePath},
 				},
+				"redacted_headers": {
+					Description: "Additional HTTP headers to mask in the debug logs. `Authorization`, `Proxy-Authorization`, `Cookie` and `Set-Cookie` are always masked.",
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"redacted_fields": {
+					Description: "Additional JSON fields to mask in the request and response bodies of the debug logs. A field matches a key at any depth, or a nested key when given as a dotted path, e.g. `token.value`. Passwords, password hashes, API keys and tokens are always masked.",
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"retry": {
+					Description: "Retry policy for requests failing with a transient error. When defined, it replaces the default retries of the Elasticsearch client.",
+					Type:        schema.TypeList,
+					MaxItems:    1,
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"max_attempts": {
+								Description:  "Maximum number of attempts for a single request, including the first one.",
+								Type:         schema.TypeInt,
+								Optional:     true,
+								Default:      3,
+								ValidateFunc: validation.IntAtLeast(1),
+							},
+							"retry_on_status": {
+								Description: "HTTP status codes which trigger a retry. Defaults to `429`, `502`, `503` and `504`. The `502` and `504` gateway errors only trigger a retry of the requests which can be safely replayed.",
+								Type:        schema.TypeSet,
+								Optional:    true,
+								Elem: &schema.Schema{
+									Type:         schema.TypeInt,
+									ValidateFunc: validation.IntBetween(400, 599),
+								},
+							},
+							"initial_backoff": {
+								Description:  "Wait before the first retry, doubled on every following attempt and randomized with jitter, e.g. `1s`.",
+								Type:         schema.TypeString,
+								Optional:     true,
+								Default:      "1s",
+								ValidateFunc: validateDuration,
+							},
+							"max_wait": {
+								Description:  "Maximum wait between two attempts, also applied to the `Retry-After` header sent by the server, e.g. `30s`.",
+								Type:         schema.TypeString,
+								Optional:     true,
+								Default:      "30s",
+								ValidateFunc: validateDuration,
+							},
+						},
+					},
+				},
 			},
 		},
 	}
 }
 
-func GetKibanaConnectionSchema() *schema.Schema {
+func GetKibanaConnectionSchema(keyName string, isProviderConfiguration bool) *schema.Schema {
+	usernamePath := makePathRef(keyName, "username")
+	passwordPath := makePathRef(keyName, "password")
+	caFilePath := makePathRef(keyName, "ca_file")
+	caDataPath := makePathRef(keyName, "ca_data")
+	certFilePath := makePathRef(keyName, "cert_file")
+	certDataPath := makePathRef(keyName, "cert_data")
+	keyFilePath := makePathRef(keyName, "key_file")
+	keyDataPath := makePathRef(keyName, "key_data")
+
+	withEnvDefault := func(key string, dv interface{}) schema.SchemaDefaultFunc { return nil }
+	description := "Kibana connection configuration block. When defined, it takes precedence over the provider configuration for this resource."
+
+	if isProviderConfiguration {
+		withEnvDefault = func(key string, dv interface{}) schema.SchemaDefaultFunc { return schema.EnvDefaultFunc(key, dv) }
+		description = "Kibana connection configuration block."
+	}
+
 	return &schema.Schema{
-		Description: "Kibana connection configuration block.",
+		Description: description,
 		Type:        schema.TypeList,
 		MaxItems:    1,
 		Optional:    true,
@@ -135,14 +230,14 @@ func GetKibanaConnectionSchema() *schema.Schema {
 					Description:  "Username to use for API authentication to Kibana.",
 					Type:         schema.TypeString,
 					Optional:     true,
-					RequiredWith: []string{"kibana.0.password"},
+					RequiredWith: []string{passwordPath},
 				},
 				"password": {
 					Description:  "Password to use for API authentication to Kibana.",
 					Type:         schema.TypeString,
 					Optional:     true,
 					Sensitive:    true,
-					RequiredWith: []string{"kibana.0.username"},
+					RequiredWith: []string{usernamePath},
 				},
 				"endpoints": {
 					Description: "A list of endpoints where the terraform provider will point to, this must include the http(s) schema and port number.",
@@ -154,12 +249,79 @@ func GetKibanaConnectionSchema() *schema.Schema {
 						Type: schema.TypeString,
 					},
 				},
+				"api_key": {
+					Description:   "API Key to use for authentication to Kibana",
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					DefaultFunc:   withEnvDefault("KIBANA_API_KEY", nil),
+					ConflictsWith: []string{usernamePath, passwordPath},
+				},
 				"insecure": {
 					Description: "Disable TLS certificate validation",
 					Type:        schema.TypeBool,
 					Optional:    true,
 					Default:     false,
 				},
+				"ca_file": {
+					Description:   "Path to a custom Certificate Authority certificate",
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   withEnvDefault("KIBANA_CA_FILE", nil),
+					ConflictsWith: []string{caDataPath},
+				},
+				"ca_data": {
+					Description:   "PEM-encoded custom Certificate Authority certificate",
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   withEnvDefault("KIBANA_CA_DATA", nil),
+					ConflictsWith: []string{caFilePath},
+				},
+				"cert_file": {
+					Description:   "Path to a file containing the PEM encoded certificate for client auth",
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   withEnvDefault("KIBANA_CERT_FILE", nil),
+					ConflictsWith: []string{certDataPath, keyDataPath},
+				},
+				"key_file": {
+					Description:   "Path to a file containing the PEM encoded private key for client auth",
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   withEnvDefault("KIBANA_KEY_FILE", nil),
+					ConflictsWith: []string{certDataPath, keyDataPath},
+				},
+				"cert_data": {
+					Description:   "PEM encoded certificate for client auth",
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   withEnvDefault("KIBANA_CERT_DATA", nil),
+					ConflictsWith: []string{certFilePath, keyFilePath},
+				},
+				"key_data": {
+					Description:   "PEM encoded private key for client auth",
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					DefaultFunc:   withEnvDefault("KIBANA_KEY_DATA", nil),
+					ConflictsWith: []string{certFilePath, keyFilePath},
+				},
+				"redacted_headers": {
+					Description: "Additional HTTP headers to mask in the debug logs. `Authorization`, `Proxy-Authorization`, `Cookie` and `Set-Cookie` are always masked.",
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"redacted_fields": {
+					Description: "Additional JSON fields to mask in the request and response bodies of the debug logs. A field matches a key at any depth, or a nested key when given as a dotted path, e.g. `token.value`. Passwords, password hashes, API keys and tokens are always masked.",
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
 			},
 		},
 	}
@@ -168,3 +330,17 @@ func GetKibanaConnectionSchema() *schema.Schema {
 func makePathRef(keyName string, keyValue string) string {
 	return fmt.Sprintf("%s.0.%s", keyName, keyValue)
 }
+
+// validateDuration is the same as utils.StringIsDuration, which can't be used here since utils depends on this package.
+func validateDuration(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if _, err := time.ParseDuration(v); err != nil {
+		return nil, []error{fmt.Errorf("%q contains an invalid duration: %s", k, err)}
+	}
+
+	return nil, nil
+}
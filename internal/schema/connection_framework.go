@@ -0,0 +1,357 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The plugin framework counterparts of GetEsConnectionSchema and GetKibanaConnectionSchema.
+// The provider configuration schema must be identical to the one of the SDK provider,
+// since both are served by the same muxed provider server.
+
+func GetEsFWConnectionBlock() providerschema.Block {
+	return providerschema.ListNestedBlock{
+		MarkdownDescription: "Elasticsearch connection configuration block. ",
+		NestedObject: providerschema.NestedBlockObject{
+			Attributes: map[string]providerschema.Attribute{
+				"username": providerschema.StringAttribute{
+					MarkdownDescription: "Username to use for API authentication to Elasticsearch.",
+					Optional:            true,
+				},
+				"password": providerschema.StringAttribute{
+					MarkdownDescription: "Password to use for API authentication to Elasticsearch.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"api_key": providerschema.StringAttribute{
+					MarkdownDescription: "API Key to use for authentication to Elasticsearch",
+					Optional:            true,
+					Sensitive:           true,
+					Validators:          []validator.String{conflictsWith("username", "password", "bearer_token")},
+				},
+				"bearer_token": providerschema.StringAttribute{
+					MarkdownDescription: "Bearer token to use for authentication to Elasticsearch, e.g. a service account token.",
+					Optional:            true,
+					Sensitive:           true,
+					Validators:          []validator.String{conflictsWith("username", "password", "api_key")},
+				},
+				"endpoints": providerschema.ListAttribute{
+					MarkdownDescription: "A list of endpoints where the terraform provider will point to, this must include the http(s) schema and port number.",
+					Optional:            true,
+					Sensitive:           true,
+					ElementType:         types.StringType,
+					Validators:          []validator.List{listvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("cloud_id"))},
+				},
+				"cloud_id": providerschema.StringAttribute{
+					MarkdownDescription: "Elastic Cloud ID of the deployment, used instead of `endpoints`. The Kibana endpoint is derived from it as well unless configured explicitly.",
+					Optional:            true,
+					Validators:          []validator.String{conflictsWith("endpoints")},
+				},
+				"insecure": providerschema.BoolAttribute{
+					MarkdownDescription: "Disable TLS certificate validation",
+					Optional:            true,
+				},
+				"ca_file": providerschema.StringAttribute{
+					MarkdownDescription: "Path to a custom Certificate Authority certificate",
+					Optional:            true,
+					Validators:          []validator.String{conflictsWith("ca_data")},
+				},
+				"ca_data": providerschema.StringAttribute{
+					MarkdownDescription: "PEM-encoded custom Certificate Authority certificate",
+					Optional:            true,
+					Validators:          []validator.String{conflictsWith("ca_file")},
+				},
+				"cert_file": providerschema.StringAttribute{
+					MarkdownDescription: "Path to a file containing the PEM encoded certificate for client auth",
+					Optional:            true,
+					Validators:          []validator.String{alsoRequires("key_file"), conflictsWith("cert_data", "key_data")},
+				},
+				"key_file": providerschema.StringAttribute{
+					MarkdownDescription: "Path to a file containing the PEM encoded private key for client auth",
+					Optional:            true,
+					Validators:          []validator.String{alsoRequires("cert_file"), conflictsWith("cert_data", "key_data")},
+				},
+				"cert_data": providerschema.StringAttribute{
+					MarkdownDescription: "PEM encoded certificate for client auth",
+					Optional:            true,
+					Validators:          []validator.String{alsoRequires("key_data"), conflictsWith("cert_file", "key_file")},
+				},
+				"key_data": providerschema.StringAttribute{
+					MarkdownDescription: "PEM encoded private key for client auth",
+					Optional:            true,
+					Sensitive:           true,
+					Validators:          []validator.String{alsoRequires("cert_data"), conflictsWith("cert_file", "key_file")},
+				},
+				"redacted_headers": providerschema.SetAttribute{
+					MarkdownDescription: "Additional HTTP headers to mask in the debug logs. `Authorization`, `Proxy-Authorization`, `Cookie` and `Set-Cookie` are always masked.",
+					Optional:            true,
+					ElementType:         types.StringType,
+				},
+				"redacted_fields": providerschema.SetAttribute{
+					MarkdownDescription: "Additional JSON fields to mask in the request and response bodies of the debug logs. A field matches a key at any depth, or a nested key when given as a dotted path, e.g. `token.value`. Passwords, password hashes, API keys and tokens are always masked.",
+					Optional:            true,
+					ElementType:         types.StringType,
+				},
+			},
+			Blocks: map[string]providerschema.Block{
+				"retry": providerschema.ListNestedBlock{
+					MarkdownDescription: "Retry policy for requests failing with a transient error. When defined, it replaces the default retries of the Elasticsearch client.",
+					NestedObject: providerschema.NestedBlockObject{
+						Attributes: map[string]providerschema.Attribute{
+							"max_attempts": providerschema.Int64Attribute{
+								MarkdownDescription: "Maximum number of attempts for a single request, including the first one.",
+								Optional:            true,
+								Validators:          []validator.Int64{int64validator.AtLeast(1)},
+							},
+							"retry_on_status": providerschema.SetAttribute{
+								MarkdownDescription: "HTTP status codes which trigger a retry. Defaults to `429`, `502`, `503` and `504`. The `502` and `504` gateway errors only trigger a retry of the requests which can be safely replayed.",
+								Optional:            true,
+								ElementType:         types.Int64Type,
+								Validators:          []validator.Set{setvalidator.ValueInt64sAre(int64validator.Between(400, 599))},
+							},
+							"initial_backoff": providerschema.StringAttribute{
+								MarkdownDescription: "Wait before the first retry, doubled on every following attempt and randomized with jitter, e.g. `1s`.",
+								Optional:            true,
+							},
+							"max_wait": providerschema.StringAttribute{
+								MarkdownDescription: "Maximum wait between two attempts, also applied to the `Retry-After` header sent by the server, e.g. `30s`.",
+								Optional:            true,
+							},
+						},
+					},
+					Validators: []validator.List{listvalidator.SizeAtMost(1)},
+				},
+			},
+		},
+		Validators: []validator.List{listvalidator.SizeAtMost(1)},
+	}
+}
+
+func GetKibanaFWConnectionBlock() providerschema.Block {
+	return providerschema.ListNestedBlock{
+		MarkdownDescription: "Kibana connection configuration block.",
+		NestedObject: providerschema.NestedBlockObject{
+			Attributes: map[string]providerschema.Attribute{
+				"username": providerschema.StringAttribute{
+					MarkdownDescription: "Username to use for API authentication to Kibana.",
+					Optional:            true,
+					Validators:          []validator.String{alsoRequires("password")},
+				},
+				"password": providerschema.StringAttribute{
+					MarkdownDescription: "Password to use for API authentication to Kibana.",
+					Optional:            true,
+					Sensitive:           true,
+					Validators:          []validator.String{alsoRequires("username")},
+				},
+				"endpoints": providerschema.ListAttribute{
+					MarkdownDescription: "A list of endpoints where the terraform provider will point to, this must include the http(s) schema and port number.",
+					Optional:            true,
+					Sensitive:           true,
+					ElementType:         types.StringType,
+					Validators:          []validator.List{listvalidator.SizeAtMost(1)},
+				},
+				"api_key": providerschema.StringAttribute{
+					MarkdownDescription: "API Key to use for authentication to Kibana",
+					Optional:            true,
+					Sensitive:           true,
+					Validators:          []validator.String{conflictsWith("username", "password")},
+				},
+				"insecure": providerschema.BoolAttribute{
+					MarkdownDescription: "Disable TLS certificate validation",
+					Optional:            true,
+				},
+				"ca_file": providerschema.StringAttribute{
+					MarkdownDescription: "Path to a custom Certificate Authority certificate",
+					Optional:            true,
+					Validators:          []validator.String{conflictsWith("ca_data")},
+				},
+				"ca_data": providerschema.StringAttribute{
+					MarkdownDescription: "PEM-encoded custom Certificate Authority certificate",
+					Optional:            true,
+					Validators:          []validator.String{conflictsWith("ca_file")},
+				},
+				"cert_file": providerschema.StringAttribute{
+					MarkdownDescription: "Path to a file containing the PEM encoded certificate for client auth",
+					Optional:            true,
+					Validators:          []validator.String{conflictsWith("cert_data", "key_data")},
+				},
+				"key_file": providerschema.StringAttribute{
+					MarkdownDescription: "Path to a file containing the PEM encoded private key for client auth",
+					Optional:            true,
+					Validators:          []validator.String{conflictsWith("cert_data", "key_data")},
+				},
+				"cert_data": providerschema.StringAttribute{
+					MarkdownDescription: "PEM encoded certificate for client auth",
+					Optional:            true,
+					Validators:          []validator.String{conflictsWith("cert_file", "key_file")},
+				},
+				"key_data": providerschema.StringAttribute{
+					MarkdownDescription: "PEM encoded private key for client auth",
+					Optional:            true,
+					Sensitive:           true,
+					Validators:          []validator.String{conflictsWith("cert_file", "key_file")},
+				},
+				"redacted_headers": providerschema.SetAttribute{
+					MarkdownDescription: "Additional HTTP headers to mask in the debug logs. `Authorization`, `Proxy-Authorization`, `Cookie` and `Set-Cookie` are always masked.",
+					Optional:            true,
+					ElementType:         types.StringType,
+				},
+				"redacted_fields": providerschema.SetAttribute{
+					MarkdownDescription: "Additional JSON fields to mask in the request and response bodies of the debug logs. A field matches a key at any depth, or a nested key when given as a dotted path, e.g. `token.value`. Passwords, password hashes, API keys and tokens are always masked.",
+					Optional:            true,
+					ElementType:         types.StringType,
+				},
+			},
+		},
+		Validators: []validator.List{listvalidator.SizeAtMost(1)},
+	}
+}
+
+// GetEsFWResourceConnectionBlock returns the deprecated elasticsearch_connection block of the plugin framework resources.
+func GetEsFWResourceConnectionBlock() resourceschema.Block {
+	deprecationMessage := "This property will be removed in a future provider version. Configure the Elasticsearch connection via the provider configuration instead."
+
+	return resourceschema.ListNestedBlock{
+		MarkdownDescription: fmt.Sprintf("Elasticsearch connection configuration block. %s", deprecationMessage),
+		DeprecationMessage:  deprecationMessage,
+		NestedObject: resourceschema.NestedBlockObject{
+			Attributes: map[string]resourceschema.Attribute{
+				"username": resourceschema.StringAttribute{
+					MarkdownDescription: "Username to use for API authentication to Elasticsearch.",
+					Optional:            true,
+					Validators:          []validator.String{alsoRequires("password")},
+				},
+				"password": resourceschema.StringAttribute{
+					MarkdownDescription: "Password to use for API authentication to Elasticsearch.",
+					Optional:            true,
+					Sensitive:           true,
+					Validators:          []validator.String{alsoRequires("username")},
+				},
+				"api_key": resourceschema.StringAttribute{
+					MarkdownDescription: "API Key to use for authentication to Elasticsearch",
+					Optional:            true,
+					Sensitive:           true,
+					Validators:          []validator.String{conflictsWith("username", "password", "bearer_token")},
+				},
+				"bearer_token": resourceschema.StringAttribute{
+					MarkdownDescription: "Bearer token to use for authentication to Elasticsearch, e.g. a service account token.",
+					Optional:            true,
+					Sensitive:           true,
+					Validators:          []validator.String{conflictsWith("username", "password", "api_key")},
+				},
+				"endpoints": resourceschema.ListAttribute{
+					MarkdownDescription: "A list of endpoints where the terraform provider will point to, this must include the http(s) schema and port number.",
+					Optional:            true,
+					Sensitive:           true,
+					ElementType:         types.StringType,
+					Validators:          []validator.List{listvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("cloud_id"))},
+				},
+				"cloud_id": resourceschema.StringAttribute{
+					MarkdownDescription: "Elastic Cloud ID of the deployment, used instead of `endpoints`. The Kibana endpoint is derived from it as well unless configured explicitly.",
+					Optional:            true,
+					Validators:          []validator.String{conflictsWith("endpoints")},
+				},
+				"insecure": resourceschema.BoolAttribute{
+					MarkdownDescription: "Disable TLS certificate validation",
+					Optional:            true,
+				},
+				"ca_file": resourceschema.StringAttribute{
+					MarkdownDescription: "Path to a custom Certificate Authority certificate",
+					Optional:            true,
+					Validators:          []validator.String{conflictsWith("ca_data")},
+				},
+				"ca_data": resourceschema.StringAttribute{
+					MarkdownDescription: "PEM-encoded custom Certificate Authority certificate",
+					Optional:            true,
+					Validators:          []validator.String{conflictsWith("ca_file")},
+				},
+				"cert_file": resourceschema.StringAttribute{
+					MarkdownDescription: "Path to a file containing the PEM encoded certificate for client auth",
+					Optional:            true,
+					Validators:          []validator.String{alsoRequires("key_file"), conflictsWith("cert_data", "key_data")},
+				},
+				"key_file": resourceschema.StringAttribute{
+					MarkdownDescription: "Path to a file containing the PEM encoded private key for client auth",
+					Optional:            true,
+					Validators:          []validator.String{alsoRequires("cert_file"), conflictsWith("cert_data", "key_data")},
+				},
+				"cert_data": resourceschema.StringAttribute{
+					MarkdownDescription: "PEM encoded certificate for client auth",
+					Optional:            true,
+					Validators:          []validator.String{alsoRequires("key_data"), conflictsWith("cert_file", "key_file")},
+				},
+				"key_data": resourceschema.StringAttribute{
+					MarkdownDescription: "PEM encoded private key for client auth",
+					Optional:            true,
+					Sensitive:           true,
+					Validators:          []validator.String{alsoRequires("cert_data"), conflictsWith("cert_file", "key_file")},
+				},
+				"redacted_headers": resourceschema.SetAttribute{
+					MarkdownDescription: "Additional HTTP headers to mask in the debug logs. `Authorization`, `Proxy-Authorization`, `Cookie` and `Set-Cookie` are always masked.",
+					Optional:            true,
+					ElementType:         types.StringType,
+				},
+				"redacted_fields": resourceschema.SetAttribute{
+					MarkdownDescription: "Additional JSON fields to mask in the request and response bodies of the debug logs. A field matches a key at any depth, or a nested key when given as a dotted path, e.g. `token.value`. Passwords, password hashes, API keys and tokens are always masked.",
+					Optional:            true,
+					ElementType:         types.StringType,
+				},
+			},
+			Blocks: map[string]resourceschema.Block{
+				"retry": resourceschema.ListNestedBlock{
+					MarkdownDescription: "Retry policy for requests failing with a transient error. When defined, it replaces the default retries of the Elasticsearch client.",
+					NestedObject: resourceschema.NestedBlockObject{
+						Attributes: map[string]resourceschema.Attribute{
+							"max_attempts": resourceschema.Int64Attribute{
+								MarkdownDescription: "Maximum number of attempts for a single request, including the first one.",
+								Optional:            true,
+								Validators:          []validator.Int64{int64validator.AtLeast(1)},
+							},
+							"retry_on_status": resourceschema.SetAttribute{
+								MarkdownDescription: "HTTP status codes which trigger a retry. Defaults to `429`, `502`, `503` and `504`. The `502` and `504` gateway errors only trigger a retry of the requests which can be safely replayed.",
+								Optional:            true,
+								ElementType:         types.Int64Type,
+								Validators:          []validator.Set{setvalidator.ValueInt64sAre(int64validator.Between(400, 599))},
+							},
+							"initial_backoff": resourceschema.StringAttribute{
+								MarkdownDescription: "Wait before the first retry, doubled on every following attempt and randomized with jitter, e.g. `1s`.",
+								Optional:            true,
+							},
+							"max_wait": resourceschema.StringAttribute{
+								MarkdownDescription: "Maximum wait between two attempts, also applied to the `Retry-After` header sent by the server, e.g. `30s`.",
+								Optional:            true,
+							},
+						},
+					},
+					Validators: []validator.List{listvalidator.SizeAtMost(1)},
+				},
+			},
+		},
+		Validators: []validator.List{listvalidator.SizeAtMost(1)},
+	}
+}
+
+func conflictsWith(attributes ...string) validator.String {
+	return stringvalidator.ConflictsWith(siblingPaths(attributes)...)
+}
+
+func alsoRequires(attributes ...string) validator.String {
+	return stringvalidator.AlsoRequires(siblingPaths(attributes)...)
+}
+
+func siblingPaths(attributes []string) []path.Expression {
+	var paths []path.Expression
+	for _, a := range attributes {
+		paths = append(paths, path.MatchRelative().AtParent().AtName(a))
+	}
+	return paths
+}
@@ -0,0 +1,19 @@
+package utils
+
+import (
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// FrameworkDiagsFromSDK converts the SDK diagnostics returned by the shared helpers into plugin framework diagnostics.
+func FrameworkDiagsFromSDK(sdkDiags diag.Diagnostics) fwdiag.Diagnostics {
+	var diags fwdiag.Diagnostics
+	for _, d := range sdkDiags {
+		if d.Severity == diag.Error {
+			diags.AddError(d.Summary, d.Detail)
+		} else {
+			diags.AddWarning(d.Summary, d.Detail)
+		}
+	}
+	return diags
+}
@@ -1,10 +1,12 @@
 package utils
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -13,6 +15,45 @@ func DiffJsonSuppress(k, old, new string, d *schema.ResourceData) bool {
 	return result
 }
 
+// DiffElasticDurationSuppress suppresses the diff of equivalent durations using the Elastic time units, e.g. `1d`
+// and `24h`.
+func DiffElasticDurationSuppress(k, old, new string, d *schema.ResourceData) bool {
+	o, err := ParseElasticDuration(old)
+	if err != nil {
+		return false
+	}
+	n, err := ParseElasticDuration(new)
+	if err != nil {
+		return false
+	}
+	return o == n
+}
+
+// DiffJsonSuppressModifier is the plugin framework counterpart of DiffJsonSuppress, keeping the
+// value of the state when the planned JSON is equivalent.
+func DiffJsonSuppressModifier() planmodifier.String {
+	return diffJsonSuppressModifier{}
+}
+
+type diffJsonSuppressModifier struct{}
+
+func (m diffJsonSuppressModifier) Description(_ context.Context) string {
+	return "Keeps the value of the state when the planned JSON is equivalent."
+}
+
+func (m diffJsonSuppressModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m diffJsonSuppressModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+	if equal, _ := JSONBytesEqual([]byte(req.StateValue.ValueString()), []byte(req.PlanValue.ValueString())); equal {
+		resp.PlanValue = req.StateValue
+	}
+}
+
 func DiffIndexSettingSuppress(k, old, new string, d *schema.ResourceData) bool {
 	var o, n map[string]interface{}
 	if err := json.Unmarshal([]byte(old), &o); err != nil {
@@ -9,3 +9,28 @@ func ExpandStringSet(set *schema.Set) []string {
 	}
 	return strs
 }
+
+// DataSourceSchemaFromResourceSchema returns the schema of a data source reading the objects managed by a
+// resource, all the attributes being computed. The connection block is left out, to be added by the data source.
+func DataSourceSchemaFromResourceSchema(resourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	result := make(map[string]*schema.Schema, len(resourceSchema))
+	for key, s := range resourceSchema {
+		if key == connectionKeyName {
+			continue
+		}
+		computed := &schema.Schema{
+			Type:        s.Type,
+			Description: s.Description,
+			Computed:    true,
+			Sensitive:   s.Sensitive,
+		}
+		switch elem := s.Elem.(type) {
+		case *schema.Resource:
+			computed.Elem = &schema.Resource{Schema: DataSourceSchemaFromResourceSchema(elem.Schema)}
+		case *schema.Schema:
+			computed.Elem = &schema.Schema{Type: elem.Type}
+		}
+		result[key] = computed
+	}
+	return result
+}
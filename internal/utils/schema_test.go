@@ -38,3 +38,51 @@ func TestExpandStringSet(t *testing.T) {
 		})
 	}
 }
+
+func TestDataSourceSchemaFromResourceSchema(t *testing.T) {
+	t.Parallel()
+
+	resourceSchema := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"tags": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"block": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"enabled": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+				},
+			},
+		},
+	}
+	AddConnectionSchema(resourceSchema)
+
+	dataSourceSchema := DataSourceSchemaFromResourceSchema(resourceSchema)
+	if _, ok := dataSourceSchema[connectionKeyName]; ok {
+		t.Errorf("expected the connection block to be left out")
+	}
+	dataSourceSchema["name"].Computed = false
+	dataSourceSchema["name"].Required = true
+	AddConnectionSchema(dataSourceSchema)
+	dataSource := &schema.Resource{Schema: dataSourceSchema}
+	if err := dataSource.InternalValidate(nil, false); err != nil {
+		t.Errorf("expected a valid data source schema, got %v", err)
+	}
+	enabled := dataSourceSchema["block"].Elem.(*schema.Resource).Schema["enabled"]
+	if !enabled.Computed || enabled.Optional || enabled.Default != nil {
+		t.Errorf("expected the nested attributes to be computed, got %+v", enabled)
+	}
+}
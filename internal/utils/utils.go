@@ -6,7 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -129,6 +132,14 @@ func AddConnectionSchema(providedSchema map[string]*schema.Schema) {
 	providedSchema[connectionKeyName] = providerSchema.GetEsConnectionSchema(connectionKeyName, false)
 }
 
+const kibanaConnectionKeyName = "kibana_connection"
+
+// Returns the common connection schema for all the Kibana resources,
+// which defines the fields which can be used to configure the API access
+func AddKibanaConnectionSchema(providedSchema map[string]*schema.Schema) {
+	providedSchema[kibanaConnectionKeyName] = providerSchema.GetKibanaConnectionSchema(kibanaConnectionKeyName, false)
+}
+
 func StringToHash(s string) (*string, error) {
 	h := sha1.New()
 	_, err := h.Write([]byte(s))
@@ -140,6 +151,31 @@ func StringToHash(s string) (*string, error) {
 	return &hash, nil
 }
 
+var elasticDurationRegex = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)(d|h|ms|micros|m|s|nanos)$`)
+
+var elasticTimeUnits = map[string]time.Duration{
+	"d":      24 * time.Hour,
+	"h":      time.Hour,
+	"m":      time.Minute,
+	"s":      time.Second,
+	"ms":     time.Millisecond,
+	"micros": time.Microsecond,
+	"nanos":  time.Nanosecond,
+}
+
+// ParseElasticDuration parses a duration using the Elastic time units, e.g. `1d` or `1.5h`.
+func ParseElasticDuration(s string) (time.Duration, error) {
+	matches := elasticDurationRegex.FindStringSubmatch(s)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid duration %q: not conforming to Elastic time-units format", s)
+	}
+	value, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+	return time.Duration(math.Round(value * float64(elasticTimeUnits[matches[2]]))), nil
+}
+
 func FormatStrictDateTime(t time.Time) string {
 	strictDateTime := t.Format("2006-01-02T15:04:05.000Z")
 	return strictDateTime
@@ -149,7 +185,8 @@ func ExpandIndividuallyDefinedSettings(ctx context.Context, d *schema.ResourceDa
 	settings := make(map[string]interface{})
 	for key := range settingsKeys {
 		tfFieldKey := ConvertSettingsKeyToTFFieldKey(key)
-		if raw, ok := d.GetOk(tfFieldKey); ok {
+		// GetOk doesn't report the zero values, like `number_of_replicas = 0`, which are looked up in the configuration
+		if raw, ok := d.GetOk(tfFieldKey); ok || isScalarConfigured(d, tfFieldKey) {
 			switch field := raw.(type) {
 			case *schema.Set:
 				settings[key] = field.List()
@@ -162,6 +199,16 @@ func ExpandIndividuallyDefinedSettings(ctx context.Context, d *schema.ResourceDa
 	return settings
 }
 
+// isScalarConfigured checks whether the top level scalar attribute is set in the configuration.
+func isScalarConfigured(d *schema.ResourceData, key string) bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsObjectType() || !raw.Type().HasAttribute(key) {
+		return false
+	}
+	v := raw.GetAttr(key)
+	return v.IsKnown() && !v.IsNull() && v.Type().IsPrimitiveType()
+}
+
 func ConvertSettingsKeyToTFFieldKey(settingKey string) string {
 	return strings.Replace(settingKey, ".", "_", -1)
 }
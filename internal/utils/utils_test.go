@@ -2,6 +2,7 @@ package utils_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
 )
@@ -84,3 +85,40 @@ func TestDiffIndexTemplateSuppress(t *testing.T) {
 		}
 	}
 }
+
+func TestParseElasticDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in       string
+		expected time.Duration
+		err      bool
+	}{
+		{in: "1d", expected: 24 * time.Hour},
+		{in: "1.5h", expected: 90 * time.Minute},
+		{in: "30m", expected: 30 * time.Minute},
+		{in: "86400000ms", expected: 24 * time.Hour},
+		{in: "10micros", expected: 10 * time.Microsecond},
+		{in: "10nanos", expected: 10 * time.Nanosecond},
+		{in: "", err: true},
+		{in: "1w", err: true},
+		{in: "-1d", err: true},
+	}
+
+	for _, tc := range tests {
+		d, err := utils.ParseElasticDuration(tc.in)
+		if tc.err {
+			if err == nil {
+				t.Errorf("expected an error for %q", tc.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", tc.in, err)
+			continue
+		}
+		if d != tc.expected {
+			t.Errorf("expected %q to be %s, got %s", tc.in, tc.expected, d)
+		}
+	}
+}
@@ -1,9 +1,13 @@
 package utils
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
 
 // StringIsDuration is a SchemaValidateFunc which tests to make sure the supplied string is valid duration.
@@ -40,3 +44,29 @@ func StringIsElasticDuration(i interface{}, k string) (warnings []string, errors
 
 	return nil, nil
 }
+
+// StringIsJSONValidator is the plugin framework counterpart of validation.StringIsJSON.
+func StringIsJSONValidator() validator.String {
+	return stringIsJSONValidator{}
+}
+
+type stringIsJSONValidator struct{}
+
+func (v stringIsJSONValidator) Description(_ context.Context) string {
+	return "value must be a valid JSON string"
+}
+
+func (v stringIsJSONValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringIsJSONValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var js interface{}
+	if err := json.Unmarshal([]byte(req.ConfigValue.ValueString()), &js); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid JSON", fmt.Sprintf("%q contains an invalid JSON: %s", req.Path, err))
+	}
+}
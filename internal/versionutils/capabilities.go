@@ -0,0 +1,137 @@
+package versionutils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type capability struct {
+	minVersion *version.Version
+	// omitted capabilities are left out of the requests sent to older servers rather than failing the plan
+	omitted bool
+}
+
+func requires(minVersion string) capability {
+	return capability{minVersion: version.Must(version.NewVersion(minVersion))}
+}
+
+func omittedBefore(minVersion string) capability {
+	return capability{minVersion: version.Must(version.NewVersion(minVersion)), omitted: true}
+}
+
+// capabilities holds the minimum Elasticsearch version required by the resources and their attributes,
+// keyed by resource type and attribute path. The path of nested attributes is dot separated, without the
+// indices of the blocks, e.g. `hot.rollover.min_age`. The empty path holds the requirement of the resource itself.
+var capabilities = map[string]map[string]capability{
+	"elasticstack_elasticsearch_transform": {
+		"":                        requires("7.2.0"),
+		"destination.pipeline":    requires("7.3.0"),
+		"frequency":               requires("7.3.0"),
+		"latest":                  requires("7.11.0"),
+		"retention_policy":        requires("7.12.0"),
+		"source.runtime_mappings": requires("7.12.0"),
+		"metadata":                requires("7.16.0"),
+		"docs_per_second":         requires("7.8.0"),
+		"max_page_search_size":    requires("7.8.0"),
+		"dates_as_epoch_millis":   requires("7.11.0"),
+		"align_checkpoints":       requires("7.15.0"),
+		"deduce_mappings":         requires("8.1.0"),
+		"num_failure_retries":     requires("8.4.0"),
+		"unattended":              requires("8.5.0"),
+		"timeout":                 omittedBefore("7.17.0"),
+	},
+	"elasticstack_elasticsearch_index_lifecycle": {
+		"hot.rollover.min_age":                requires("8.4.0"),
+		"hot.rollover.min_docs":               requires("8.4.0"),
+		"hot.rollover.min_size":               requires("8.4.0"),
+		"hot.rollover.min_primary_shard_size": requires("8.4.0"),
+		"hot.rollover.min_primary_shard_docs": requires("8.4.0"),
+		"warm.allocate.total_shards_per_node": requires("7.16.0"),
+		"cold.allocate.total_shards_per_node": requires("7.16.0"),
+	},
+	"elasticstack_elasticsearch_security_api_key": {
+		"metadata": requires("7.13.0"),
+	},
+	"elasticstack_elasticsearch_security_cross_cluster_api_key": {
+		"": requires("8.10.0"),
+	},
+	"elasticstack_elasticsearch_security_role": {
+		"remote_indices": requires("8.10.0"),
+	},
+	"elasticstack_elasticsearch_security_service_token": {
+		"": requires("7.13.0"),
+	},
+}
+
+// MinVersion returns the minimum Elasticsearch version supporting the attribute of the resource,
+// nil when the attribute isn't restricted.
+func MinVersion(resource, path string) *version.Version {
+	return capabilities[resource][path].minVersion
+}
+
+// IsSupported checks whether the attribute of the resource is supported by the Elasticsearch server version.
+func IsSupported(resource, path string, serverVersion *version.Version) bool {
+	minVersion := MinVersion(resource, path)
+	return minVersion == nil || serverVersion.GreaterThanOrEqual(minVersion)
+}
+
+// CheckCapabilities returns a CustomizeDiff function which fails the plan when the resource, or one of its
+// configured attributes, isn't supported by the target Elasticsearch server.
+//
+// The server is only queried when the configuration includes an attribute with a version requirement, or
+// when the resource, having a version requirement itself, is created.
+func CheckCapabilities(resource string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		var paths []string
+		for path, c := range capabilities[resource] {
+			if c.omitted {
+				continue
+			}
+			if path == "" {
+				if d.Id() == "" {
+					paths = append(paths, path)
+				}
+				continue
+			}
+			if isConfigured(d.GetRawConfig(), strings.Split(path, ".")) {
+				paths = append(paths, path)
+			}
+		}
+		if len(paths) == 0 {
+			return nil
+		}
+
+		client, diags := clients.NewApiClientFromDiff(d, meta)
+		if diags.HasError() {
+			return fmt.Errorf("failed to get the Elasticsearch client: %v", diags)
+		}
+		serverVersion, diags := client.ServerVersion(ctx)
+		if diags.HasError() {
+			return fmt.Errorf("failed to get the Elasticsearch version: %v", diags)
+		}
+
+		sort.Strings(paths)
+		var unsupported []string
+		for _, path := range paths {
+			if IsSupported(resource, path, serverVersion) {
+				continue
+			}
+			minVersion := MinVersion(resource, path)
+			if path == "" {
+				unsupported = append(unsupported, fmt.Sprintf("%s requires a minimum Elasticsearch version of %s", resource, minVersion))
+				continue
+			}
+			unsupported = append(unsupported, fmt.Sprintf("[%s] requires a minimum Elasticsearch version of %s", path, minVersion))
+		}
+		if len(unsupported) > 0 {
+			return fmt.Errorf("the target Elasticsearch server version %s isn't supported: %s", serverVersion, strings.Join(unsupported, "; "))
+		}
+		return nil
+	}
+}
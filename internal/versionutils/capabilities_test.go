@@ -0,0 +1,115 @@
+package versionutils_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest/fakeserver"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// useFakeServer points the tests to a fake server reporting the given version.
+func useFakeServer(t *testing.T, version string) {
+	server := fakeserver.New(fakeserver.WithVersion(version))
+	t.Cleanup(server.Close)
+
+	t.Setenv("ELASTICSEARCH_ENDPOINTS", server.URL)
+	t.Setenv("KIBANA_ENDPOINT", server.URL)
+	t.Setenv("ELASTICSEARCH_USERNAME", "elastic")
+	t.Setenv("ELASTICSEARCH_PASSWORD", "changeme")
+	t.Setenv("ELASTICSEARCH_API_KEY", "")
+}
+
+func TestAccCheckCapabilities(t *testing.T) {
+	useFakeServer(t, "7.10.2")
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccTransformConfig(name, "deduce_mappings = true"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`\[deduce_mappings\] requires a minimum Elasticsearch version of 8.1.0`),
+			},
+			{
+				Config:      testAccIlmConfig(name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`\[hot.rollover.min_docs\] requires a minimum Elasticsearch version of 8.4.0`),
+			},
+			{
+				// the timeout is left out of the requests to older servers
+				Config: testAccTransformConfig(name, `timeout = "1m"`),
+				Check:  resource.TestCheckResourceAttr("elasticstack_elasticsearch_transform.test", "name", name),
+			},
+		},
+	})
+}
+
+func TestAccCheckCapabilitiesResource(t *testing.T) {
+	useFakeServer(t, "7.1.1")
+	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccTransformConfig(name, ""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`elasticstack_elasticsearch_transform requires a minimum Elasticsearch version of 7.2.0`),
+			},
+		},
+	})
+}
+
+func testAccTransformConfig(name, extra string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_transform" "test" {
+  name = "%s"
+
+  source {
+    indices = ["source-index"]
+  }
+
+  destination {
+    index = "dest-index"
+  }
+
+  pivot = jsonencode({
+    group_by = { customer_id = { terms = { field = "customer_id" } } }
+    aggregations = { max_price = { max = { field = "price" } } }
+  })
+
+  defer_validation = true
+  %s
+}
+`, name, extra)
+}
+
+func testAccIlmConfig(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_lifecycle" "test" {
+  name = "%s"
+
+  hot {
+    rollover {
+      max_age  = "1d"
+      min_docs = 1000
+    }
+  }
+}
+`, name)
+}
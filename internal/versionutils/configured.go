@@ -0,0 +1,39 @@
+package versionutils
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+)
+
+// isConfigured checks whether the attribute at the path is set in the configuration, in any of the blocks.
+// Unknown values are considered as configured.
+func isConfigured(v cty.Value, path []string) bool {
+	if v.IsNull() {
+		return false
+	}
+	if !v.IsKnown() {
+		return true
+	}
+	ty := v.Type()
+	if len(path) == 0 {
+		// blocks which aren't configured are empty collections rather than null values
+		if ty.IsListType() || ty.IsSetType() {
+			return v.LengthInt() > 0
+		}
+		return true
+	}
+
+	switch {
+	case ty.IsObjectType():
+		if !ty.HasAttribute(path[0]) {
+			return false
+		}
+		return isConfigured(v.GetAttr(path[0]), path[1:])
+	case ty.IsListType() || ty.IsSetType() || ty.IsTupleType():
+		for it := v.ElementIterator(); it.Next(); {
+			if _, elem := it.Element(); isConfigured(elem, path) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package versionutils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestIsConfigured(t *testing.T) {
+	rollover := func(minDocs cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"hot": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"rollover": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					"max_age":  cty.StringVal("1d"),
+					"min_docs": minDocs,
+				})}),
+			})}),
+			"warm": cty.ListValEmpty(cty.EmptyObject),
+		})
+	}
+
+	tests := []struct {
+		name     string
+		config   cty.Value
+		path     string
+		expected bool
+	}{
+		{name: "nested attribute", config: rollover(cty.NumberIntVal(10)), path: "hot.rollover.min_docs", expected: true},
+		{name: "null nested attribute", config: rollover(cty.NullVal(cty.Number)), path: "hot.rollover.min_docs", expected: false},
+		{name: "unknown nested attribute", config: rollover(cty.UnknownVal(cty.Number)), path: "hot.rollover.min_docs", expected: true},
+		{name: "block", config: rollover(cty.NullVal(cty.Number)), path: "hot.rollover", expected: true},
+		{name: "empty block", config: rollover(cty.NullVal(cty.Number)), path: "warm", expected: false},
+		{name: "missing attribute", config: rollover(cty.NullVal(cty.Number)), path: "cold.allocate", expected: false},
+		{name: "null config", config: cty.NullVal(cty.EmptyObject), path: "hot", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isConfigured(tt.config, strings.Split(tt.path, ".")); got != tt.expected {
+				t.Errorf("isConfigured(%s) = %v, expected %v", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
@@ -8,6 +8,20 @@ import (
 	"github.com/hashicorp/go-version"
 )
 
+// APIKeysEnabledVersion is the first version enabling API keys by default on clusters without TLS,
+// older test clusters don't support the acceptance tests relying on API keys.
+var APIKeysEnabledVersion = version.Must(version.NewVersion("8.0.0"))
+
+// CheckIfNotSupported skips the test step when the attribute of the resource isn't supported by the
+// test cluster, according to the version registry.
+func CheckIfNotSupported(resource, path string) func() (bool, error) {
+	minVersion := MinVersion(resource, path)
+	if minVersion == nil {
+		return func() (bool, error) { return false, nil }
+	}
+	return CheckIfVersionIsUnsupported(minVersion)
+}
+
 func CheckIfVersionIsUnsupported(minSupportedVersion *version.Version) func() (bool, error) {
 	return func() (b bool, err error) {
 		client, err := clients.NewAcceptanceTestingClient()
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 )
@@ -12,6 +13,7 @@ func ProtoV5ProviderServerFactory(ctx context.Context, version string) (func() t
 	sdkv2Provider := New(version)
 
 	servers := []func() tfprotov5.ProviderServer{
+		providerserver.NewProtocol5(NewFrameworkProvider(version)),
 		sdkv2Provider.GRPCProvider,
 	}
 
@@ -0,0 +1,28 @@
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+func TestMuxServer(t *testing.T) {
+	factory, err := provider.ProtoV5ProviderServerFactory(context.Background(), "dev")
+	if err != nil {
+		t.Fatalf("Failed to create the mux server: %s", err)
+	}
+
+	// the mux server fails when the providers don't share the same provider schema
+	res, err := factory().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("Failed to get the provider schema: %s", err)
+	}
+	for _, d := range res.Diagnostics {
+		t.Errorf("%s: %s", d.Summary, d.Detail)
+	}
+	if _, ok := res.ResourceSchemas["elasticstack_elasticsearch_script"]; !ok {
+		t.Error("Expected the plugin framework resources to be served")
+	}
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/cluster"
+	providerSchema "github.com/elastic/terraform-provider-elasticstack/internal/schema"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+var _ fwprovider.Provider = &Provider{}
+
+// Provider serves the resources implemented with the plugin framework.
+// It is muxed with the SDK provider and shares its configuration.
+type Provider struct {
+	version string
+}
+
+// NewFrameworkProvider instantiates the plugin framework based provider.
+func NewFrameworkProvider(version string) fwprovider.Provider {
+	return &Provider{
+		version: version,
+	}
+}
+
+func (p *Provider) Metadata(ctx context.Context, req fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "elasticstack"
+	resp.Version = p.version
+}
+
+func (p *Provider) Schema(ctx context.Context, req fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	resp.Schema = fwschema.Schema{
+		Blocks: map[string]fwschema.Block{
+			esKeyName:     providerSchema.GetEsFWConnectionBlock(),
+			kibanaKeyName: providerSchema.GetKibanaFWConnectionBlock(),
+		},
+	}
+}
+
+func (p *Provider) Configure(ctx context.Context, req fwprovider.ConfigureRequest, res *fwprovider.ConfigureResponse) {
+	var config clients.ProviderConfiguration
+	res.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := clients.NewApiClientFromFramework(ctx, config, p.version)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.DataSourceData = client
+	res.ResourceData = client
+}
+
+func (p *Provider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		cluster.NewScriptResource,
+	}
+}
+
+func (p *Provider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{}
+}
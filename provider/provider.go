@@ -16,6 +16,7 @@ import (
 )
 
 const esKeyName = "elasticsearch"
+const kibanaKeyName = "kibana"
 
 func init() {
 	// Set descriptions to support markdown syntax, this will be used in document generation
@@ -26,10 +27,16 @@ func init() {
 func New(version string) *schema.Provider {
 	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
-			esKeyName: providerSchema.GetEsConnectionSchema(esKeyName, true),
-			"kibana":  providerSchema.GetKibanaConnectionSchema(),
+			esKeyName:     providerSchema.GetEsConnectionSchema(esKeyName, true),
+			kibanaKeyName: providerSchema.GetKibanaConnectionSchema(kibanaKeyName, true),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
+			"elasticstack_elasticsearch_component_template":                 index.DataSourceComponentTemplate(),
+			"elasticstack_elasticsearch_index":                              index.DataSourceIndex(),
+			"elasticstack_elasticsearch_index_lifecycle":                    index.DataSourceIlm(),
+			"elasticstack_elasticsearch_index_template":                     index.DataSourceTemplate(),
+			"elasticstack_elasticsearch_index_template_simulate":            index.DataSourceTemplateSimulate(),
+			"elasticstack_elasticsearch_indices":                            index.DataSourceIndices(),
 			"elasticstack_elasticsearch_ingest_processor_append":            ingest.DataSourceProcessorAppend(),
 			"elasticstack_elasticsearch_ingest_processor_bytes":             ingest.DataSourceProcessorBytes(),
 			"elasticstack_elasticsearch_ingest_processor_circle":            ingest.DataSourceProcessorCircle(),
@@ -70,29 +77,35 @@ func New(version string) *schema.Provider {
 			"elasticstack_elasticsearch_ingest_processor_user_agent":        ingest.DataSourceProcessorUserAgent(),
 			"elasticstack_elasticsearch_security_role":                      security.DataSourceRole(),
 			"elasticstack_elasticsearch_security_role_mapping":              security.DataSourceRoleMapping(),
+			"elasticstack_elasticsearch_security_service_accounts":          security.DataSourceServiceAccounts(),
 			"elasticstack_elasticsearch_security_user":                      security.DataSourceUser(),
 			"elasticstack_elasticsearch_snapshot_repository":                cluster.DataSourceSnapshotRespository(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"elasticstack_elasticsearch_cluster_settings":      cluster.ResourceSettings(),
-			"elasticstack_elasticsearch_component_template":    index.ResourceComponentTemplate(),
-			"elasticstack_elasticsearch_data_stream":           index.ResourceDataStream(),
-			"elasticstack_elasticsearch_index":                 index.ResourceIndex(),
-			"elasticstack_elasticsearch_index_lifecycle":       index.ResourceIlm(),
-			"elasticstack_elasticsearch_index_template":        index.ResourceTemplate(),
-			"elasticstack_elasticsearch_ingest_pipeline":       ingest.ResourceIngestPipeline(),
-			"elasticstack_elasticsearch_logstash_pipeline":     logstash.ResourceLogstashPipeline(),
-			"elasticstack_elasticsearch_security_api_key":      security.ResourceApiKey(),
-			"elasticstack_elasticsearch_security_role":         security.ResourceRole(),
-			"elasticstack_elasticsearch_security_role_mapping": security.ResourceRoleMapping(),
-			"elasticstack_elasticsearch_security_user":         security.ResourceUser(),
-			"elasticstack_elasticsearch_security_system_user":  security.ResourceSystemUser(),
-			"elasticstack_elasticsearch_snapshot_lifecycle":    cluster.ResourceSlm(),
-			"elasticstack_elasticsearch_snapshot_repository":   cluster.ResourceSnapshotRepository(),
-			"elasticstack_elasticsearch_script":                cluster.ResourceScript(),
-			"elasticstack_elasticsearch_enrich_policy":         enrich.ResourceEnrichPolicy(),
-			"elasticstack_elasticsearch_transform":             transform.ResourceTransform(),
-			"elasticstack_elasticsearch_watch":                 watcher.ResourceWatch(),
+			"elasticstack_elasticsearch_cluster_settings":               cluster.ResourceSettings(),
+			"elasticstack_elasticsearch_component_template":             index.ResourceComponentTemplate(),
+			"elasticstack_elasticsearch_data_stream":                    index.ResourceDataStream(),
+			"elasticstack_elasticsearch_index":                          index.ResourceIndex(),
+			"elasticstack_elasticsearch_index_alias":                    index.ResourceAlias(),
+			"elasticstack_elasticsearch_index_resize":                   index.ResourceIndexResize(),
+			"elasticstack_elasticsearch_index_settings":                 index.ResourceIndexSettings(),
+			"elasticstack_elasticsearch_index_lifecycle":                index.ResourceIlm(),
+			"elasticstack_elasticsearch_index_template":                 index.ResourceTemplate(),
+			"elasticstack_elasticsearch_ingest_pipeline":                ingest.ResourceIngestPipeline(),
+			"elasticstack_elasticsearch_logstash_pipeline":              logstash.ResourceLogstashPipeline(),
+			"elasticstack_elasticsearch_security_api_key":               security.ResourceApiKey(),
+			"elasticstack_elasticsearch_security_application_privilege": security.ResourceApplicationPrivilege(),
+			"elasticstack_elasticsearch_security_cross_cluster_api_key": security.ResourceCrossClusterApiKey(),
+			"elasticstack_elasticsearch_security_role":                  security.ResourceRole(),
+			"elasticstack_elasticsearch_security_role_mapping":          security.ResourceRoleMapping(),
+			"elasticstack_elasticsearch_security_service_token":         security.ResourceServiceToken(),
+			"elasticstack_elasticsearch_security_user":                  security.ResourceUser(),
+			"elasticstack_elasticsearch_security_system_user":           security.ResourceSystemUser(),
+			"elasticstack_elasticsearch_snapshot_lifecycle":             cluster.ResourceSlm(),
+			"elasticstack_elasticsearch_snapshot_repository":            cluster.ResourceSnapshotRepository(),
+			"elasticstack_elasticsearch_enrich_policy":                  enrich.ResourceEnrichPolicy(),
+			"elasticstack_elasticsearch_transform":                      transform.ResourceTransform(),
+			"elasticstack_elasticsearch_watch":                          watcher.ResourceWatch(),
 
 			"elasticstack_kibana_space": kibana.ResourceSpace(),
 		},
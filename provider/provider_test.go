@@ -6,7 +6,6 @@ import (
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
-	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/security"
 	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	"github.com/elastic/terraform-provider-elasticstack/provider"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -26,7 +25,7 @@ func TestElasticsearchAPIKeyConnection(t *testing.T) {
 		ProtoV5ProviderFactories: acctest.Providers,
 		Steps: []resource.TestStep{
 			{
-				SkipFunc: versionutils.CheckIfVersionIsUnsupported(security.APIKeyMinVersion),
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(versionutils.APIKeysEnabledVersion),
 				Config:   testElasticsearchConnection(apiKeyName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_security_user.test", "username", "elastic"),
@@ -36,6 +35,23 @@ func TestElasticsearchAPIKeyConnection(t *testing.T) {
 	})
 }
 
+func TestKibanaConnection(t *testing.T) {
+	spaceId := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testKibanaConnection(spaceId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_kibana_space.test_connection", "space_id", spaceId),
+					resource.TestCheckResourceAttr("elasticstack_kibana_space.test_connection", "kibana_connection.#", "1"),
+				),
+			},
+		},
+	})
+}
+
 func testElasticsearchConnection(apiKeyName string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
@@ -70,3 +86,25 @@ data "elasticstack_elasticsearch_security_user" "test" {
 }
 `, apiKeyName, os.Getenv("ELASTICSEARCH_ENDPOINTS"))
 }
+
+func testKibanaConnection(spaceId string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+  kibana {
+    endpoints = ["http://localhost:1"]
+  }
+}
+
+resource "elasticstack_kibana_space" "test_connection" {
+  space_id = "%s"
+  name     = "Connection %s"
+
+  kibana_connection {
+    endpoints = ["%s"]
+    username  = "%s"
+    password  = "%s"
+  }
+}
+`, spaceId, spaceId, os.Getenv("KIBANA_ENDPOINT"), os.Getenv("ELASTICSEARCH_USERNAME"), os.Getenv("ELASTICSEARCH_PASSWORD"))
+}